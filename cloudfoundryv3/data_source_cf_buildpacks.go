@@ -0,0 +1,105 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceBuildpacks() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceBuildpacksRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"stack": {
+				Description: "Only list the buildpacks of this stack",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"buildpacks": {
+				Description: "Buildpacks in auto-detection order",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stack": {
+							Description: "Empty when the buildpack applies to any stack",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"position": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"locked": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"filename": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildpacksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	query := []ccv3.Query{
+		{Key: ccv3.OrderBy, Values: []string{ccv3.PositionOrder}},
+	}
+	if stack, ok := d.GetOk("stack"); ok {
+		query = append(query, ccv3.Query{Key: ccv3.StackFilter, Values: []string{stack.(string)}})
+	}
+	bps, warns, err := session.ClientV3.GetBuildpacks(query...)
+	diags = append(diags, diagFromClient("get-buildpacks", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	tfBuildpacks := make([]interface{}, 0, len(bps))
+	for _, bp := range bps {
+		tfBuildpacks = append(tfBuildpacks, map[string]interface{}{
+			"id":       bp.GUID,
+			"name":     bp.Name,
+			"stack":    bp.Stack,
+			"position": bp.Position.Value,
+			"enabled":  bp.Enabled.Value,
+			"locked":   bp.Locked.Value,
+			"filename": bp.Filename,
+			"state":    bp.State,
+		})
+	}
+
+	d.SetId(session.ApiEndpoint + "/buildpacks/" + d.Get("stack").(string))
+	_ = d.Set("buildpacks", tfBuildpacks)
+
+	return diags
+}
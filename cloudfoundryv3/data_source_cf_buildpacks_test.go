@@ -0,0 +1,57 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceBuildpacks_normal(t *testing.T) {
+
+	ref := "data.cloudfoundry_buildpacks.bps"
+
+	resource.ParallelTest(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: `
+						data "cloudfoundry_buildpacks" "bps" {
+							stack = "cflinuxfs3"
+						}
+					`,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(ref, "buildpacks.0.name"),
+						resource.TestCheckResourceAttr(ref, "buildpacks.0.stack", "cflinuxfs3"),
+						checkDataSourceBuildpacksOrdered(ref),
+					),
+				},
+			},
+		})
+}
+
+func checkDataSourceBuildpacksOrdered(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("buildpacks '%s' not found in terraform state", resource)
+		}
+
+		count, _ := strconv.Atoi(rs.Primary.Attributes["buildpacks.#"])
+		previous := 0
+		for i := 0; i < count; i++ {
+			position, _ := strconv.Atoi(rs.Primary.Attributes[fmt.Sprintf("buildpacks.%d.position", i)])
+			if position < previous {
+				return fmt.Errorf("buildpack %d has position %d after position %d", i, position, previous)
+			}
+			previous = position
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceEnvironmentVariableGroup() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceEnvironmentVariableGroupRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Description:  "Name of the group, one of: running or staging",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(environmentVariableGroups, false),
+			},
+
+			"variables": {
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceEnvironmentVariableGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	name := d.Get("name").(string)
+	vars, warns, err := session.ClientV3.GetEnvironmentVariableGroup(constant.EnvironmentVariableGroupName(name))
+	diags = append(diags, diagFromClient("get-environment-variable-group", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	tfVars := make(map[string]interface{}, len(vars))
+	for key, v := range vars {
+		tfVars[key] = v.Value
+	}
+
+	d.SetId(name)
+	_ = d.Set("variables", tfVars)
+
+	return diags
+}
@@ -0,0 +1,70 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceFeatureFlags() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceFeatureFlagsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"flag": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"custom_error_message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"enabled": {
+				Description: "Whether each flag is enabled, by flag name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+			},
+		},
+	}
+}
+
+func dataSourceFeatureFlagsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	flags, diags := getFeatureFlags(session)
+	if diags.HasError() {
+		return diags
+	}
+
+	tfFlags := make([]interface{}, 0, len(flags))
+	enabled := make(map[string]interface{}, len(flags))
+	for _, flag := range flags {
+		tfFlags = append(tfFlags, featureFlagToResource(flag))
+		enabled[flag.Name] = flag.Enabled
+	}
+
+	d.SetId(featureFlagsID)
+	_ = d.Set("flag", tfFlags)
+	_ = d.Set("enabled", enabled)
+
+	return diags
+}
@@ -0,0 +1,66 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceIsolationSegment() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceIsolationSegmentRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"orgs": {
+				Description: "GUIDs of the organizations entitled to the segment",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func dataSourceIsolationSegmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	segments, warns, err := session.ClientV3.GetIsolationSegments(
+		ccv3.Query{Key: ccv3.NameFilter, Values: []string{d.Get("name").(string)}},
+	)
+	diags = append(diags, diagFromClient("get-isolation-segments", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(segments) == 0 {
+		return append(diags, diag.FromErr(NotFound)...)
+	}
+	d.SetId(segments[0].GUID)
+
+	orgs, warns, err := session.ClientV3.GetIsolationSegmentOrganizations(d.Id())
+	diags = append(diags, diagFromClient("get-isolation-segment-orgs", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	guids := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		guids = append(guids, org.GUID)
+	}
+	_ = d.Set("orgs", guids)
+
+	return append(diags, metadataRead(isolationSegmentMetadata, d, meta, true)...)
+}
@@ -3,8 +3,7 @@ package cloudfoundry
 import (
 	"fmt"
 
-	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
-	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
 	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,8 +21,12 @@ func dataSourceOrg() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			// labelsKey:      labelsSchema(),
-			// annotationsKey: annotationsSchema(),
+			"quota": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
 		},
 	}
 }
@@ -37,12 +40,8 @@ func dataSourceOrgRead(d *schema.ResourceData, meta interface{}) error {
 
 	name := d.Get("name").(string)
 
-	orgs, _, err := session.ClientV2.GetOrganizations(
-		ccv2.Filter{
-			Type:     constant.NameFilter,
-			Operator: constant.EqualOperator,
-			Values:   []string{name},
-		},
+	orgs, _, err := session.ClientV3.GetOrganizations(
+		ccv3.Query{Key: ccv3.NameFilter, Values: []string{name}},
 	)
 	if err != nil {
 		return err
@@ -52,10 +51,7 @@ func dataSourceOrgRead(d *schema.ResourceData, meta interface{}) error {
 		return NotFound
 	}
 	d.SetId(orgs[0].GUID)
+	_ = d.Set("quota", orgs[0].QuotaGUID)
 
-	// err = metadataRead(orgMetadata, d, meta, true)
-	// if err != nil {
-	// 	return err
-	// }
-	return err
+	return diagsToError(metadataRead(orgMetadata, d, meta, true))
 }
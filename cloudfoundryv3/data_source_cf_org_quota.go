@@ -0,0 +1,54 @@
+package cloudfoundry
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceOrgQuota() *schema.Resource {
+
+	s := quotaSchema(true, true)
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["orgs"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Set:      schema.HashString,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceOrgQuotaRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceOrgQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	var list struct {
+		Resources []quota `json:"resources"`
+	}
+	query := url.Values{"names": []string{d.Get("name").(string)}}
+	_, warns, err := rawRequest(session, "GET", "/v3/organization_quotas?"+query.Encode(), nil, &list)
+	diags = append(diags, diagFromClient("get-org-quotas", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(list.Resources) == 0 {
+		return append(diags, diag.FromErr(NotFound)...)
+	}
+
+	q := list.Resources[0]
+	d.SetId(q.GUID)
+	quotaToResource(q, d, true)
+	_ = d.Set("orgs", q.Relationships.Organizations.GUIDs)
+
+	return diags
+}
@@ -0,0 +1,32 @@
+package cloudfoundry_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOrgQuota_normal(t *testing.T) {
+
+	ref := "data.cloudfoundry_org_quota.default"
+
+	resource.ParallelTest(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: `
+						data "cloudfoundry_org_quota" "default" {
+							name = "default"
+						}
+					`,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(ref, "id"),
+						resource.TestCheckResourceAttrSet(ref, "total_memory_in_mb"),
+						resource.TestCheckResourceAttrSet(ref, "log_rate_limit_in_bytes_per_second"),
+					),
+				},
+			},
+		})
+}
@@ -0,0 +1,57 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/router/routererror"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRouterGroup() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceRouterGroupRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"reservable_ports": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRouterGroupRead(d *schema.ResourceData, meta interface{}) error {
+
+	session := meta.(*managers.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	name := d.Get("name").(string)
+
+	routerGroup, err := session.RouterClient.GetRouterGroupByName(name)
+	if err != nil {
+		if _, ok := err.(routererror.ResourceNotFoundError); ok {
+			return NotFound
+		}
+		return err
+	}
+
+	d.SetId(routerGroup.GUID)
+	_ = d.Set("type", routerGroup.Type)
+	_ = d.Set("reservable_ports", routerGroup.ReservablePorts)
+	return nil
+}
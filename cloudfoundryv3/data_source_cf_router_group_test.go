@@ -0,0 +1,64 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceRouterGroup_normal(t *testing.T) {
+
+	ref := "data.cloudfoundry_router_group.rg"
+
+	src := `
+		data "cloudfoundry_router_group" "rg" {
+			name = "default-tcp"
+		}
+	`
+
+	resource.ParallelTest(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: src,
+					Check: resource.ComposeTestCheckFunc(
+						checkDataSourceRouterGroupExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "name", "default-tcp"),
+						resource.TestCheckResourceAttr(
+							ref, "type", "tcp"),
+					),
+				},
+			},
+		})
+}
+
+func checkDataSourceRouterGroupExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("router_group '%s' not found in terraform state", resource)
+		}
+
+		id := rs.Primary.ID
+		name := rs.Primary.Attributes["name"]
+
+		routerGroup, err := testAccEnv.Session.RouterClient.GetRouterGroupByName(name)
+		if err != nil {
+			return err
+		}
+		if id != routerGroup.GUID {
+			return fmt.Errorf("id not match")
+		}
+		if rs.Primary.Attributes["type"] != routerGroup.Type {
+			return fmt.Errorf("type not match")
+		}
+
+		return nil
+	}
+}
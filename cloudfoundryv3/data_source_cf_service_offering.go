@@ -0,0 +1,148 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceServiceOffering() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceServiceOfferingRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"service_broker_name": {
+				Description: "Name of the broker of the offering, when several brokers register an offering with the same name",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"space": {
+				Description:   "GUID of a space the offering must be available in",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"org"},
+			},
+
+			"org": {
+				Description: "GUID of an organization the offering must be available in",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"service_broker": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"shareable": {
+				Description: "Whether instances of the offering can be shared with other spaces",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"service_plans": {
+				Description: "GUIDs of the plans of the offering visible in the space or organization, by name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func dataSourceServiceOfferingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	name := d.Get("name").(string)
+
+	filters := serviceAvailabilityFilters(d)
+	query := append([]ccv3.Query{{Key: ccv3.NameFilter, Values: []string{name}}}, filters...)
+	if broker := d.Get("service_broker_name").(string); broker != "" {
+		query = append(query, ccv3.Query{Key: ccv3.ServiceBrokerNamesFilter, Values: []string{broker}})
+	}
+
+	offerings, warns, err := session.ClientV3.GetServiceOfferings(query...)
+	diags = append(diags, diagFromClient("get-service-offerings", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	switch {
+	case len(offerings) == 0:
+		return append(diags, diag.FromErr(NotFound)...)
+	case len(offerings) > 1:
+		return append(diags, diag.FromErr(fmt.Errorf("service offering %s is registered by several brokers, set service_broker_name", name))...)
+	}
+	offering := offerings[0]
+	d.SetId(offering.GUID)
+
+	plans, warns, err := session.ClientV3.GetServicePlans(
+		append([]ccv3.Query{{Key: ccv3.ServiceOfferingGUIDsFilter, Values: []string{offering.GUID}}}, filters...)...,
+	)
+	diags = append(diags, diagFromClient("get-service-plans", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	tfPlans := make(map[string]interface{}, len(plans))
+	for _, plan := range plans {
+		tfPlans[plan.Name] = plan.GUID
+	}
+
+	broker, warns, err := session.ClientV3.GetServiceBrokers(
+		ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{offering.ServiceBrokerGUID}},
+	)
+	diags = append(diags, diagFromClient("get-service-broker", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(broker) > 0 {
+		_ = d.Set("service_broker_name", broker[0].Name)
+	}
+
+	_ = d.Set("service_broker", offering.ServiceBrokerGUID)
+	_ = d.Set("description", offering.Description)
+	_ = d.Set("shareable", offering.AllowsInstanceSharing)
+	_ = d.Set("tags", offering.Tags.Value)
+	_ = d.Set("service_plans", tfPlans)
+
+	return append(diags, metadataRead(serviceOfferingMetadata, d, meta, true)...)
+}
+
+// serviceAvailabilityFilters restricts offerings and plans to the ones
+// visible in the space or organization of the data source
+func serviceAvailabilityFilters(d *schema.ResourceData) []ccv3.Query {
+	var filters []ccv3.Query
+	if space := d.Get("space").(string); space != "" {
+		filters = append(filters, ccv3.Query{Key: ccv3.SpaceGUIDFilter, Values: []string{space}})
+	}
+	if org := d.Get("org").(string); org != "" {
+		filters = append(filters, ccv3.Query{Key: ccv3.OrganizationGUIDFilter, Values: []string{org}})
+	}
+	return filters
+}
@@ -0,0 +1,77 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceServiceOffering_normal(t *testing.T) {
+
+	src := `
+		data "cloudfoundry_service_offering" "offering" {
+			name = %q
+			service_broker_name = %q
+			space = %q
+		}
+		data "cloudfoundry_service_plan" "plan" {
+			name = %q
+			service_offering_name = data.cloudfoundry_service_offering.offering.name
+			service_broker_name = %q
+			space = %q
+		}
+	`
+
+	offering := testAccEnv.ServiceOffering
+	plan := testAccEnv.ServicePlan
+	broker := testAccEnv.ServiceBroker
+	space := testAccEnv.Space.GUID
+
+	ref := "data.cloudfoundry_service_offering.offering"
+	refPlan := "data.cloudfoundry_service_plan.plan"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, offering.Name, broker.Name, space, plan.Name, broker.Name, space),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(ref, "id", offering.GUID),
+						resource.TestCheckResourceAttr(ref, "service_broker", broker.GUID),
+						resource.TestCheckResourceAttr(ref, "service_plans."+plan.Name, plan.GUID),
+						resource.TestCheckResourceAttr(refPlan, "id", plan.GUID),
+						resource.TestCheckResourceAttr(refPlan, "service_offering", offering.GUID),
+						resource.TestCheckResourceAttr(refPlan, "free", fmt.Sprintf("%t", plan.Free)),
+						resource.TestCheckResourceAttr(refPlan, "maintenance_info_version", plan.MaintenanceInfoVersion),
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestAccDataSourceServicePlan_notFound(t *testing.T) {
+
+	src := `
+		data "cloudfoundry_service_plan" "plan" {
+			name = "tf-acc-no-such-plan"
+			service_offering_name = %q
+		}
+	`
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(src, testAccEnv.ServiceOffering.Name),
+					ExpectError: regexp.MustCompile("not found"),
+				},
+			},
+		},
+	)
+}
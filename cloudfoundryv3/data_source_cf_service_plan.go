@@ -0,0 +1,167 @@
+package cloudfoundry
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+// servicePlanSchemas is the schemas field of /v3/service_plans, the pinned
+// cli resource does not know it
+type servicePlanSchemas struct {
+	Schemas struct {
+		ServiceInstance struct {
+			Create servicePlanParameters `json:"create"`
+			Update servicePlanParameters `json:"update"`
+		} `json:"service_instance"`
+		ServiceBinding struct {
+			Create servicePlanParameters `json:"create"`
+		} `json:"service_binding"`
+	} `json:"schemas"`
+}
+
+type servicePlanParameters struct {
+	Parameters json.RawMessage `json:"parameters"`
+}
+
+func (p servicePlanParameters) String() string {
+	if len(p.Parameters) == 0 || string(p.Parameters) == "{}" || string(p.Parameters) == "null" {
+		return ""
+	}
+	return string(p.Parameters)
+}
+
+func dataSourceServicePlan() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceServicePlanRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"service_offering_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"service_broker_name": {
+				Description: "Name of the broker of the offering, when several brokers register an offering with the same name",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"space": {
+				Description:   "GUID of a space the plan must be available in",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"org"},
+			},
+
+			"org": {
+				Description: "GUID of an organization the plan must be available in",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"service_offering": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"free": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"available": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"visibility_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"maintenance_info_version": {
+				Description: "Version of the plan, instances on an older version can be upgraded",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"maintenance_info_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"instance_create_schema": {
+				Description: "JSON schema of the parameters of instance creation, empty when the broker does not publish it",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"instance_update_schema": {
+				Description: "JSON schema of the parameters of instance updates, empty when the broker does not publish it",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"binding_create_schema": {
+				Description: "JSON schema of the parameters of binding creation, empty when the broker does not publish it",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func dataSourceServicePlanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	plan, diags := lookupServicePlan(session,
+		d.Get("service_offering_name").(string),
+		d.Get("name").(string),
+		d.Get("service_broker_name").(string),
+		serviceAvailabilityFilters(d)...,
+	)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(plan.GUID)
+
+	var schemas servicePlanSchemas
+	_, warns, err := rawRequest(session, "GET", "/v3/service_plans/"+plan.GUID, nil, &schemas)
+	diags = append(diags, diagFromClient("get-service-plan-schemas", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	_ = d.Set("service_offering", plan.ServiceOfferingGUID)
+	_ = d.Set("description", plan.Description)
+	_ = d.Set("free", plan.Free)
+	_ = d.Set("available", plan.Available)
+	_ = d.Set("visibility_type", string(plan.VisibilityType))
+	_ = d.Set("maintenance_info_version", plan.MaintenanceInfoVersion)
+	_ = d.Set("maintenance_info_description", plan.MaintenanceInfoDescription)
+	_ = d.Set("instance_create_schema", schemas.Schemas.ServiceInstance.Create.String())
+	_ = d.Set("instance_update_schema", schemas.Schemas.ServiceInstance.Update.String())
+	_ = d.Set("binding_create_schema", schemas.Schemas.ServiceBinding.Create.String())
+
+	return append(diags, metadataRead(servicePlanMetadata, d, meta, true)...)
+}
@@ -3,8 +3,8 @@ package cloudfoundry
 import (
 	"fmt"
 
-	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
-	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
 	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,8 +38,8 @@ func dataSourceSpace() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			// labelsKey:      labelsSchema(),
-			// annotationsKey: annotationsSchema(),
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
 		},
 	}
 }
@@ -60,12 +60,8 @@ func dataSourceSpaceRead(d *schema.ResourceData, meta interface{}) (err error) {
 	orgId := d.Get("org").(string)
 	orgName := d.Get("org_name").(string)
 	if d.Get("org_name").(string) != "" {
-		orgs, _, err := session.ClientV2.GetOrganizations(
-			ccv2.Filter{
-				Type:     constant.NameFilter,
-				Operator: constant.EqualOperator,
-				Values:   []string{orgName},
-			},
+		orgs, _, err := session.ClientV3.GetOrganizations(
+			ccv3.Query{Key: ccv3.NameFilter, Values: []string{orgName}},
 		)
 		if err != nil {
 			return err
@@ -75,23 +71,15 @@ func dataSourceSpaceRead(d *schema.ResourceData, meta interface{}) (err error) {
 		}
 		orgId = orgs[0].GUID
 	} else {
-		org, _, err := session.ClientV2.GetOrganization(orgId)
+		org, _, err := session.ClientV3.GetOrganization(orgId)
 		if err != nil {
 			return err
 		}
 		orgName = org.Name
 	}
-	spaces, _, err := session.ClientV2.GetSpaces(
-		ccv2.Filter{
-			Type:     constant.NameFilter,
-			Operator: constant.EqualOperator,
-			Values:   []string{name},
-		},
-		ccv2.Filter{
-			Type:     constant.OrganizationGUIDFilter,
-			Operator: constant.EqualOperator,
-			Values:   []string{orgId},
-		},
+	spaces, _, _, err := session.ClientV3.GetSpaces(
+		ccv3.Query{Key: ccv3.NameFilter, Values: []string{name}},
+		ccv3.Query{Key: ccv3.OrganizationGUIDFilter, Values: []string{orgId}},
 	)
 	if err != nil {
 		return err
@@ -103,11 +91,7 @@ func dataSourceSpaceRead(d *schema.ResourceData, meta interface{}) (err error) {
 	d.SetId(space.GUID)
 	_ = d.Set("org_name", orgName)
 	_ = d.Set("org", orgId)
-	_ = d.Set("quota_id", space.SpaceQuotaDefinitionGUID)
+	_ = d.Set("quota_id", space.Relationships[constant.RelationshipTypeQuota].GUID)
 
-	// err = metadataRead(spaceMetadata, d, meta, true)
-	// if err != nil {
-	// 	return err
-	// }
-	return err
+	return diagsToError(metadataRead(spaceMetadata, d, meta, true))
 }
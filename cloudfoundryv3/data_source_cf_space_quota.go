@@ -0,0 +1,62 @@
+package cloudfoundry
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceSpaceQuota() *schema.Resource {
+
+	s := quotaSchema(false, true)
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["org"] = &schema.Schema{
+		Description: "GUID of the organization owning the quota",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	s["spaces"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Set:      schema.HashString,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceSpaceQuotaRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceSpaceQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	var list struct {
+		Resources []quota `json:"resources"`
+	}
+	query := url.Values{
+		"names":              []string{d.Get("name").(string)},
+		"organization_guids": []string{d.Get("org").(string)},
+	}
+	_, warns, err := rawRequest(session, "GET", "/v3/space_quotas?"+query.Encode(), nil, &list)
+	diags = append(diags, diagFromClient("get-space-quotas", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(list.Resources) == 0 {
+		return append(diags, diag.FromErr(NotFound)...)
+	}
+
+	q := list.Resources[0]
+	d.SetId(q.GUID)
+	quotaToResource(q, d, false)
+	_ = d.Set("spaces", q.Relationships.Spaces.GUIDs)
+
+	return diags
+}
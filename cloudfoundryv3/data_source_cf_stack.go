@@ -0,0 +1,76 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceStack() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceStackRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"default": {
+				Description: "Whether apps use this stack when they do not set one",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func dataSourceStackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	stacks, warns, err := session.ClientV3.GetStacks(
+		ccv3.Query{Key: ccv3.NameFilter, Values: []string{d.Get("name").(string)}},
+	)
+	diags = append(diags, diagFromClient("get-stacks", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(stacks) == 0 {
+		return append(diags, diag.FromErr(NotFound)...)
+	}
+
+	defaultStack, warns, err := getDefaultStack(session)
+	diags = append(diags, diagFromClient("get-default-stack", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	stack := stacks[0]
+	d.SetId(stack.GUID)
+	_ = d.Set("description", stack.Description)
+	_ = d.Set("default", stack.GUID == defaultStack.GUID)
+
+	return append(diags, metadataRead(stackMetadata, d, meta, true)...)
+}
+
+// getDefaultStack returns the stack used by apps which do not set one, the
+// pinned client does not know this endpoint
+func getDefaultStack(session *managers.Session) (ccv3.Stack, ccv3.Warnings, error) {
+	var stack ccv3.Stack
+	_, warns, err := rawRequest(session, "GET", "/v3/stacks/default", nil, &stack)
+	return stack, warns, err
+}
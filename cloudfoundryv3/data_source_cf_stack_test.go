@@ -0,0 +1,37 @@
+package cloudfoundry_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStack_normal(t *testing.T) {
+
+	ref := "data.cloudfoundry_stack.s"
+	refs := "data.cloudfoundry_stacks.all"
+
+	resource.ParallelTest(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: `
+						data "cloudfoundry_stacks" "all" {}
+
+						data "cloudfoundry_stack" "s" {
+							name = data.cloudfoundry_stacks.all.default
+						}
+					`,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet(refs, "default"),
+						resource.TestCheckResourceAttrSet(refs, "stacks.0.id"),
+						resource.TestCheckResourceAttrPair(ref, "name", refs, "default"),
+						resource.TestCheckResourceAttrSet(ref, "id"),
+						resource.TestCheckResourceAttr(ref, "default", "true"),
+					),
+				},
+			},
+		})
+}
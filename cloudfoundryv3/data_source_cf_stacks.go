@@ -0,0 +1,83 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func dataSourceStacks() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceStacksRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"default": {
+				Description: "Name of the stack apps use when they do not set one",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"stacks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStacksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	stacks, warns, err := session.ClientV3.GetStacks()
+	diags = append(diags, diagFromClient("get-stacks", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	defaultStack, warns, err := getDefaultStack(session)
+	diags = append(diags, diagFromClient("get-default-stack", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	tfStacks := make([]interface{}, 0, len(stacks))
+	for _, stack := range stacks {
+		tfStacks = append(tfStacks, map[string]interface{}{
+			"id":          stack.GUID,
+			"name":        stack.Name,
+			"description": stack.Description,
+			"default":     stack.GUID == defaultStack.GUID,
+		})
+	}
+
+	d.SetId(session.ApiEndpoint + "/stacks")
+	_ = d.Set("default", defaultStack.Name)
+	_ = d.Set("stacks", tfStacks)
+
+	return diags
+}
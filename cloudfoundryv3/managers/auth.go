@@ -0,0 +1,111 @@
+package managers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/uaa"
+)
+
+// GrantTypeJWTBearer is the grant exchanging a jwt issued by an identity provider trusted by uaa for uaa tokens
+const GrantTypeJWTBearer = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+// ErrTokenExpired is returned when the access token has expired and there is nothing to renew it with
+var ErrTokenExpired = errors.New("The access token has expired and no refresh token is available to renew it, set refresh_token or provide a new access_token")
+
+// tokenRefresher renews the access token of the session for the authentication wrappers of the clients,
+// when there is no refresh token it authenticates again if possible
+type tokenRefresher struct {
+	client *uaa.Client
+
+	// reauthenticate obtains new tokens without refresh token (e.g.: client credentials, jwt assertion), it may be nil
+	reauthenticate func() (uaa.RefreshedTokens, error)
+}
+
+func (r *tokenRefresher) RefreshAccessToken(refreshToken string) (uaa.RefreshedTokens, error) {
+	if refreshToken != "" {
+		return r.client.RefreshAccessToken(refreshToken)
+	}
+	if r.reauthenticate != nil {
+		return r.reauthenticate()
+	}
+	return uaa.RefreshedTokens{}, ErrTokenExpired
+}
+
+// jwtBearerGrant exchanges the given jwt assertion for uaa tokens on the token endpoint of uaa
+func jwtBearerGrant(httpClient *http.Client, tokenEndpoint, clientID, clientSecret, assertion string) (uaa.RefreshedTokens, error) {
+	body := url.Values{
+		"grant_type": {GrantTypeJWTBearer},
+		"assertion":  {assertion},
+		"client_id":  {clientID},
+	}
+	req, err := http.NewRequest(http.MethodPost, tokenEndpoint, strings.NewReader(body.Encode()))
+	if err != nil {
+		return uaa.RefreshedTokens{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return uaa.RefreshedTokens{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var uaaErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&uaaErr)
+		return uaa.RefreshedTokens{}, fmt.Errorf("uaa refused the jwt assertion (%s): %s %s", resp.Status, uaaErr.Error, uaaErr.Description)
+	}
+
+	var tokens uaa.RefreshedTokens
+	err = json.NewDecoder(resp.Body).Decode(&tokens)
+	if err != nil {
+		return uaa.RefreshedTokens{}, err
+	}
+	if tokens.AccessToken == "" {
+		return uaa.RefreshedTokens{}, fmt.Errorf("uaa did not return any access token for the jwt assertion")
+	}
+	if tokens.Type == "" {
+		tokens.Type = "bearer"
+	}
+	return tokens, nil
+}
+
+// tokenExpiry returns the expiration date of a jwt access token, the zero time when it can't be read
+func tokenExpiry(token string) time.Time {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segments[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// checkAccessToken fails when a pre-issued access token has already expired and can't be refreshed
+func checkAccessToken(accessToken, refreshToken string) error {
+	expiry := tokenExpiry(accessToken)
+	if expiry.IsZero() || time.Now().Before(expiry) || refreshToken != "" {
+		return nil
+	}
+	return fmt.Errorf("The access token expired at %s and no refresh token is available to renew it, set refresh_token or provide a new access_token", expiry.Format(time.RFC3339))
+}
@@ -0,0 +1,132 @@
+package managers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/api/uaa/uaafakes"
+)
+
+const fakeAssertion = "ci.issued.jwt"
+
+// newFakeUAA serves the token endpoint of uaa for the jwt bearer and refresh token grants
+func newFakeUAA() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/token" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.PostForm.Get("grant_type") {
+		case GrantTypeJWTBearer:
+			user, _, ok := r.BasicAuth()
+			if !ok || user != "cf" || r.PostForm.Get("client_id") != "cf" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"unauthorized","error_description":"Bad credentials"}`)
+				return
+			}
+			if r.PostForm.Get("assertion") != fakeAssertion {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"invalid_token","error_description":"Could not verify token signature."}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"jwt-access","token_type":"bearer"}`)
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"invalid_token"}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"refreshed-access","refresh_token":"refresh","token_type":"bearer"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestJWTBearerGrant(t *testing.T) {
+	server := newFakeUAA()
+	defer server.Close()
+
+	tokens, err := jwtBearerGrant(server.Client(), server.URL+"/oauth/token", "cf", "", fakeAssertion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.AuthorizationToken() != "bearer jwt-access" {
+		t.Errorf("unexpected authorization token %q", tokens.AuthorizationToken())
+	}
+
+	_, err = jwtBearerGrant(server.Client(), server.URL+"/oauth/token", "cf", "", "forged.jwt")
+	if err == nil || !strings.Contains(err.Error(), "Could not verify token signature") {
+		t.Errorf("expected the refusal of uaa, got %v", err)
+	}
+}
+
+func TestTokenRefresher(t *testing.T) {
+	server := newFakeUAA()
+	defer server.Close()
+
+	config := new(uaafakes.FakeConfig)
+	config.UAAOAuthClientReturns("cf")
+	client := uaa.NewClient(config)
+	if err := client.SetupResources(server.URL, server.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	refresher := &tokenRefresher{client: client}
+	tokens, err := refresher.RefreshAccessToken("refresh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.AccessToken != "refreshed-access" {
+		t.Errorf("unexpected access token %q", tokens.AccessToken)
+	}
+
+	if _, err := refresher.RefreshAccessToken(""); err != ErrTokenExpired {
+		t.Errorf("expected %v without refresh token, got %v", ErrTokenExpired, err)
+	}
+
+	refresher.reauthenticate = func() (uaa.RefreshedTokens, error) {
+		return jwtBearerGrant(server.Client(), client.LoginLink()+"/oauth/token", "cf", "", fakeAssertion)
+	}
+	tokens, err = refresher.RefreshAccessToken("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.AccessToken != "jwt-access" {
+		t.Errorf("expected the assertion to be exchanged again, got access token %q", tokens.AccessToken)
+	}
+}
+
+func TestCheckAccessToken(t *testing.T) {
+	jwt := func(exp time.Time) string {
+		claims, _ := json.Marshal(map[string]interface{}{"exp": exp.Unix()})
+		return "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".c2ln"
+	}
+	expired := jwt(time.Now().Add(-time.Hour))
+
+	if err := checkAccessToken(jwt(time.Now().Add(time.Hour)), ""); err != nil {
+		t.Errorf("valid token: %s", err)
+	}
+	if err := checkAccessToken(expired, "refresh"); err != nil {
+		t.Errorf("expired token with refresh token: %s", err)
+	}
+	if err := checkAccessToken("opaque", ""); err != nil {
+		t.Errorf("opaque token: %s", err)
+	}
+	if err := checkAccessToken(expired, ""); err == nil || !strings.Contains(err.Error(), "no refresh token") {
+		t.Errorf("expected an expiration error, got %v", err)
+	}
+}
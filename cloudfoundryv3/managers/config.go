@@ -2,17 +2,24 @@ package managers
 
 // Config -
 type Config struct {
-	Endpoint          string
-	User              string
-	Password          string
-	SSOPasscode       string
-	CFClientID        string
-	CFClientSecret    string
-	UaaClientID       string
-	UaaClientSecret   string
-	SkipSslValidation bool
-	AppLogsMax        int
-	PurgeWhenDelete   bool
-	DefaultQuotaName  string
-	StoreTokensPath   string
+	Endpoint              string
+	User                  string
+	Password              string
+	SSOPasscode           string
+	AccessToken           string
+	RefreshToken          string
+	JWTAssertion          string
+	CFClientID            string
+	CFClientSecret        string
+	UaaClientID           string
+	UaaClientSecret       string
+	SkipSslValidation     bool
+	CACert                string
+	ClientCert            string
+	ClientKey             string
+	AppLogsMax            int
+	PurgeWhenDelete       bool
+	DefaultQuotaName      string
+	StoreTokensPath       string
+	StoreTokensPassphrase string
 }
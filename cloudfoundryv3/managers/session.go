@@ -2,14 +2,10 @@ package managers
 
 import (
 	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net"
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
 	"code.cloudfoundry.org/cfnetworking-cli-api/cfnetworking/cfnetv1"
 	netWrapper "code.cloudfoundry.org/cfnetworking-cli-api/cfnetworking/wrapper"
@@ -48,11 +44,17 @@ type Session struct {
 	Config Config
 
 	ApiEndpoint string
+
+	// Warnings raised while creating the session (e.g.: token store failures)
+	Warnings []string
+
+	// tls configuration shared by all clients
+	tlsConfig *tls.Config
 }
 
 type CFTokens struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 func (t CFTokens) IsSet() bool {
@@ -61,10 +63,11 @@ func (t CFTokens) IsSet() bool {
 
 // NewSession -
 func NewSession(c Config) (s *Session, err error) {
-	if c.User == "" && c.CFClientID == "" {
-		return nil, fmt.Errorf("Couple of user/password or uaa_client_id/uaa_client_secret must be set")
+	hasTokens := c.AccessToken != "" || c.RefreshToken != "" || c.JWTAssertion != ""
+	if c.User == "" && c.CFClientID == "" && !hasTokens {
+		return nil, fmt.Errorf("Couple of user/password, uaa_client_id/uaa_client_secret, tokens or a jwt assertion must be set")
 	}
-	if c.User != "" && c.CFClientID == "" {
+	if (c.User != "" || hasTokens) && c.CFClientID == "" {
 		c.CFClientID = "cf"
 		c.CFClientSecret = ""
 	}
@@ -76,6 +79,10 @@ func NewSession(c Config) (s *Session, err error) {
 		ApiEndpoint:     c.Endpoint,
 		Config:          c,
 	}
+	s.tlsConfig, err = newTLSConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	config := &configv3.Config{
 		ConfigFile: configv3.JSONConfig{
 			ConfigVersion:        3,
@@ -121,12 +128,14 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 	authWrapperV2 := ccWrapper.NewUAAAuthentication(nil, config)
 	authWrapperV3 := ccWrapper.NewUAAAuthentication(nil, config)
 
+	ccWrappersV2 = append(ccWrappersV2, newCCTLSWrapper(s.tlsConfig, config.DialTimeout()))
 	ccWrappersV2 = append(ccWrappersV2, authWrapperV2)
 	ccWrappersV2 = append(ccWrappersV2, ccWrapper.NewRetryRequest(config.RequestRetryCount()))
 	if IsDebugMode() {
 		ccWrappersV2 = append(ccWrappersV2, ccWrapper.NewRequestLogger(NewRequestLogger()))
 	}
 
+	ccWrappersV3 = append(ccWrappersV3, newCCTLSWrapper(s.tlsConfig, config.DialTimeout()))
 	ccWrappersV3 = append(ccWrappersV3, authWrapperV3)
 	ccWrappersV3 = append(ccWrappersV3, ccWrapper.NewRetryRequest(config.RequestRetryCount()))
 	if IsDebugMode() {
@@ -171,6 +180,7 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 	// create an uaa client with cf_username/cf_password or client_id/client secret
 	// to use it in v2 and v3 api for authenticate requests
 	uaaClient := uaa.NewClient(config)
+	uaaClient.WrapConnection(newUAATLSWrapper(s.tlsConfig, config.UAADisableKeepAlives(), config.DialTimeout()))
 
 	uaaAuthWrapper := uaaWrapper.NewUAAAuthentication(nil, configUaa)
 	uaaClient.WrapConnection(uaaAuthWrapper)
@@ -185,9 +195,46 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 	var accessToken string
 	var refreshToken string
 	var errType string
-
-	tokFromStore := s.loadTokFromStoreIfNeed(configSess.StoreTokensPath, uaaClient.RefreshAccessToken)
-	if tokFromStore.IsSet() {
+	refresher := &tokenRefresher{client: uaaClient}
+
+	// the store stays locked until tokens are saved back to prevent concurrent runs
+	// from using the same refresh token, tokens given in the provider attributes
+	// are not stored as they don't belong to the user of the store entry
+	var store *tokenStore
+	if configSess.AccessToken == "" && configSess.RefreshToken == "" && configSess.JWTAssertion == "" {
+		store = s.openTokStoreIfNeed(configSess)
+	}
+	if store != nil {
+		defer store.Close()
+	}
+	tokFromStore := s.loadTokFromStoreIfNeed(store, uaaClient.RefreshAccessToken)
+	if configSess.AccessToken != "" {
+		// use pre-issued tokens, a refresh token is needed to go beyond the expiration of the access token
+		// the token can be given as printed by cf oauth-token
+		accessToken = strings.TrimPrefix(strings.TrimPrefix(configSess.AccessToken, "bearer "), "Bearer ")
+		refreshToken = configSess.RefreshToken
+		err = checkAccessToken(accessToken, refreshToken)
+		errType = "access_token"
+	} else if configSess.RefreshToken != "" {
+		var tokens uaa.RefreshedTokens
+		tokens, err = uaaClient.RefreshAccessToken(configSess.RefreshToken)
+		accessToken, refreshToken = tokens.AccessToken, tokens.RefreshToken
+		errType = "refresh_token"
+	} else if configSess.JWTAssertion != "" {
+		// exchange a jwt issued by an identity provider trusted by uaa (e.g.: a ci workload identity),
+		// the exchange is done again when the access token expires without refresh token
+		tokenClient := &http.Client{
+			Transport: newTransport(s.tlsConfig, config.DialTimeout()),
+		}
+		refresher.reauthenticate = func() (uaa.RefreshedTokens, error) {
+			return jwtBearerGrant(tokenClient, uaaClient.LoginLink()+"/oauth/token",
+				config.UAAOAuthClient(), config.UAAOAuthClientSecret(), configSess.JWTAssertion)
+		}
+		var tokens uaa.RefreshedTokens
+		tokens, err = refresher.reauthenticate()
+		accessToken, refreshToken = tokens.AccessToken, tokens.RefreshToken
+		errType = "jwt assertion"
+	} else if tokFromStore.IsSet() {
 		accessToken = tokFromStore.AccessToken
 		refreshToken = tokFromStore.RefreshToken
 	} else if configSess.SSOPasscode != "" {
@@ -209,27 +256,32 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 			"client_secret": config.UAAOAuthClientSecret(),
 		}, "", constant.GrantTypeClientCredentials)
 		errType = "client_id/client_secret"
+		// client credentials grant doesn't give any refresh token
+		refresher.reauthenticate = func() (uaa.RefreshedTokens, error) {
+			token, _, err := uaaClient.Authenticate(map[string]string{
+				"client_id":     config.UAAOAuthClient(),
+				"client_secret": config.UAAOAuthClientSecret(),
+			}, "", constant.GrantTypeClientCredentials)
+			return uaa.RefreshedTokens{AccessToken: token, Type: "bearer"}, err
+		}
 	}
 	if err != nil {
 		return fmt.Errorf("Error when authenticate on cf using %s: %s", errType, err)
 	}
 	if accessToken == "" {
-		return fmt.Errorf("A pair of username/password, a pair of client_id/client_secret, or a SSO passcode must be set.")
+		return fmt.Errorf("A pair of username/password, a pair of client_id/client_secret, a SSO passcode, tokens or a jwt assertion must be set.")
 	}
 
 	config.SetAccessToken(fmt.Sprintf("bearer %s", accessToken))
 	config.SetRefreshToken(refreshToken)
 
 	// Write access and refresh tokens to file if needed
-	err = s.saveTokToStoreIfNeed(configSess.StoreTokensPath, accessToken, refreshToken)
-	if err != nil {
-		return fmt.Errorf("Error when trying to save tokens to %s: %s", configSess.StoreTokensPath, err.Error())
-	}
+	s.saveTokToStoreIfNeed(store, accessToken, refreshToken)
 	// -------------------------
 	// assign uaa client to request wrappers
-	uaaAuthWrapper.SetClient(uaaClient)
-	authWrapperV2.SetClient(uaaClient)
-	authWrapperV3.SetClient(uaaClient)
+	uaaAuthWrapper.SetClient(refresher)
+	authWrapperV2.SetClient(refresher)
+	authWrapperV3.SetClient(refresher)
 	// -------------------------
 
 	// store client in the sessions
@@ -241,6 +293,7 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 	// Create uaa client with given admin client_id only if user give it
 	if configUaa.UAAOAuthClient() != "" {
 		uaaClientSess := uaa.NewClient(configUaa)
+		uaaClientSess.WrapConnection(newUAATLSWrapper(s.tlsConfig, configUaa.UAADisableKeepAlives(), configUaa.DialTimeout()))
 
 		uaaAuthWrapperSess := uaaWrapper.NewUAAAuthentication(nil, configUaa)
 		uaaClientSess.WrapConnection(uaaAuthWrapperSess)
@@ -271,13 +324,22 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 		configUaa.SetAccessToken(fmt.Sprintf("bearer %s", accessTokenSess))
 		configUaa.SetRefreshToken(refreshTokenSess)
 		s.ClientUAA = uaaClientSess
-		uaaAuthWrapperSess.SetClient(uaaClientSess)
+		if configUaa.UAAOAuthClient() == "cf" {
+			// tokens are shared with the session, they are renewed the same way
+			uaaAuthWrapperSess.SetClient(refresher)
+		} else {
+			uaaAuthWrapperSess.SetClient(uaaClientSess)
+		}
 		s.ClientUAAAPI, err = uaaapi.New(
 			ccClientV2.AuthorizationEndpoint(),
 			uaaapi.WithToken(&oauth2.Token{
 				AccessToken:  accessTokenSess,
 				RefreshToken: refreshTokenSess,
 			}),
+			uaaapi.WithClient(&http.Client{
+				Transport: newTransport(s.tlsConfig, configUaa.DialTimeout()),
+			}),
+			uaaapi.WithSkipSSLValidation(configUaa.SkipSSLValidation()),
 		)
 		if err != nil {
 			return fmt.Errorf("Error setup resource uaaapi: %s", err)
@@ -289,10 +351,11 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 	// Create cfnetworking client with uaa client authentication to call network policies
 	netUaaAuthWrapper := netWrapper.NewUAAAuthentication(nil, config)
 	netWrappers := []cfnetv1.ConnectionWrapper{
+		newNetTLSWrapper(s.tlsConfig, config.DialTimeout()),
 		netUaaAuthWrapper,
 		netWrapper.NewRetryRequest(config.RequestRetryCount()),
 	}
-	netUaaAuthWrapper.SetClient(uaaClient)
+	netUaaAuthWrapper.SetClient(refresher)
 	if IsDebugMode() {
 		netWrappers = append(netWrappers, netWrapper.NewRequestLogger(NewRequestLogger()))
 	}
@@ -309,19 +372,10 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 	// -------------------------
 	// Create raw http client with uaa client authentication to make raw request
 	authWrapperRaw := ccWrapper.NewUAAAuthentication(nil, config)
-	authWrapperRaw.SetClient(uaaClient)
+	authWrapperRaw.SetClient(refresher)
 
 	s.HttpClient = &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: config.SkipSSLValidation(),
-			},
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				KeepAlive: 30 * time.Second,
-				Timeout:   config.DialTimeout(),
-			}).DialContext,
-		},
+		Transport: newTransport(s.tlsConfig, config.DialTimeout()),
 	}
 	// -------------------------
 
@@ -339,11 +393,11 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 
 	routerWrappers := []router.ConnectionWrapper{}
 
-	rAuthWrapper := routerWrapper.NewUAAAuthentication(uaaClient, config)
+	rAuthWrapper := routerWrapper.NewUAAAuthentication(refresher, config)
 	errorWrapper := routerWrapper.NewErrorWrapper()
 	retryWrapper := newRetryRequestRouter(config.RequestRetryCount())
 
-	routerWrappers = append(routerWrappers, rAuthWrapper, retryWrapper, errorWrapper)
+	routerWrappers = append(routerWrappers, newRouterTLSWrapper(s.tlsConfig), rAuthWrapper, retryWrapper, errorWrapper)
 	routerConfig.Wrappers = routerWrappers
 
 	s.RouterClient = router.NewClient(routerConfig)
@@ -354,21 +408,42 @@ func (s *Session) init(config *configv3.Config, configUaa *configv3.Config, conf
 	return nil
 }
 
-func (s *Session) loadTokFromStoreIfNeed(storePath string, refresher func(refreshToken string) (uaa.RefreshedTokens, error)) CFTokens {
-	if storePath == "" {
-		return CFTokens{}
+func (s *Session) warn(format string, a ...interface{}) {
+	s.Warnings = append(s.Warnings, fmt.Sprintf(format, a...))
+}
+
+// openTokStoreIfNeed returns the locked token store of the session, nil if there is none or it can't be used
+func (s *Session) openTokStoreIfNeed(c Config) *tokenStore {
+	if c.StoreTokensPath == "" {
+		return nil
+	}
+	user := c.User
+	if user == "" {
+		user = c.CFClientID
 	}
-	b, err := ioutil.ReadFile(storePath)
+	store, err := openTokenStore(c.StoreTokensPath, c.Endpoint, user, c.StoreTokensPassphrase)
 	if err != nil {
+		s.warn("Token store %s is not used: %s", c.StoreTokensPath, err)
+		return nil
+	}
+	return store
+}
+
+func (s *Session) loadTokFromStoreIfNeed(store *tokenStore, refresher func(refreshToken string) (uaa.RefreshedTokens, error)) CFTokens {
+	if store == nil {
 		return CFTokens{}
 	}
-	var tokens CFTokens
-	err = json.Unmarshal(b, &tokens)
+	tokens, err := store.Load()
 	if err != nil {
+		s.warn("Tokens can't be loaded from %s: %s", store.path, err)
+		return CFTokens{}
+	}
+	if tokens.RefreshToken == "" {
 		return CFTokens{}
 	}
 	refreshed, err := refresher(tokens.RefreshToken)
 	if err != nil {
+		s.warn("Tokens loaded from %s can't be refreshed, authenticating again: %s", store.path, err)
 		return CFTokens{}
 	}
 	return CFTokens{
@@ -377,15 +452,17 @@ func (s *Session) loadTokFromStoreIfNeed(storePath string, refresher func(refres
 	}
 }
 
-func (s *Session) saveTokToStoreIfNeed(storePath, accessToken, refreshToken string) error {
-	if storePath == "" {
-		return nil
+func (s *Session) saveTokToStoreIfNeed(store *tokenStore, accessToken, refreshToken string) {
+	if store == nil {
+		return
 	}
-	b, _ := json.MarshalIndent(CFTokens{
+	err := store.Save(CFTokens{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-	}, "", "  ")
-	return ioutil.WriteFile(storePath, b, 0644)
+	})
+	if err != nil {
+		s.warn("Tokens can't be saved to %s: %s", store.path, err)
+	}
 }
 
 func IsDebugMode() bool {
@@ -0,0 +1,211 @@
+package managers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cfnetworking-cli-api/cfnetworking"
+	"code.cloudfoundry.org/cfnetworking-cli-api/cfnetworking/cfnetv1"
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/router"
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/util"
+)
+
+// newTLSConfig builds the tls configuration shared by every client of the session,
+// it trusts the system pool and the given ca_cert and presents the client certificate if set
+func newTLSConfig(c Config) (*tls.Config, error) {
+	tlsConfig := util.NewTLSConfig(nil, c.SkipSslValidation)
+
+	if c.CACert != "" {
+		caCert, err := readPEM(c.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading ca_cert: %s", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("No PEM certificate found in ca_cert")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return nil, fmt.Errorf("client_cert and client_key must be set together")
+		}
+		clientCert, err := readPEM(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading client_cert: %s", err)
+		}
+		clientKey, err := readPEM(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading client_key: %s", err)
+		}
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading client_cert/client_key pair: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns the given value if it is a PEM content, otherwise the content of the file it points to
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
+
+// newTransport returns an http transport using the tls configuration of the session
+func newTransport(tlsConfig *tls.Config, dialTimeout time.Duration) *http.Transport {
+	return &http.Transport{
+		TLSClientConfig: tlsConfig.Clone(),
+		Proxy:           http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			KeepAlive: 30 * time.Second,
+			Timeout:   dialTimeout,
+		}).DialContext,
+	}
+}
+
+// setTLSConfig replaces the tls configuration of a connection made by the cli libraries,
+// they only support skipping ssl validation
+func setTLSConfig(client *http.Client, tlsConfig *tls.Config) {
+	if tr, ok := client.Transport.(*http.Transport); ok {
+		tr.TLSClientConfig = tlsConfig.Clone()
+	}
+}
+
+// ccTLSWrapper must be the first wrapper of cloud controller clients, it replaces
+// the default connection behind the error wrapper of the client by one using the tls configuration of the session
+type ccTLSWrapper struct {
+	tlsConfig   *tls.Config
+	dialTimeout time.Duration
+	connection  cloudcontroller.Connection
+}
+
+func newCCTLSWrapper(tlsConfig *tls.Config, dialTimeout time.Duration) *ccTLSWrapper {
+	return &ccTLSWrapper{
+		tlsConfig:   tlsConfig,
+		dialTimeout: dialTimeout,
+	}
+}
+
+func (w *ccTLSWrapper) Make(request *cloudcontroller.Request, passedResponse *cloudcontroller.Response) error {
+	return w.connection.Make(request, passedResponse)
+}
+
+// Wrap returns the inner error wrapper of the client wrapping a connection with the tls configuration of the session
+func (w *ccTLSWrapper) Wrap(innerconnection cloudcontroller.Connection) cloudcontroller.Connection {
+	connection := cloudcontroller.NewConnection(cloudcontroller.Config{
+		DialTimeout: w.dialTimeout,
+	})
+	setTLSConfig(connection.HTTPClient, w.tlsConfig)
+	w.connection = connection
+
+	if errorWrapper, ok := innerconnection.(ccv3.ConnectionWrapper); ok {
+		return errorWrapper.Wrap(connection)
+	}
+	return connection
+}
+
+// uaaTLSWrapper is the equivalent of ccTLSWrapper for uaa clients,
+// it must be wrapped on the client before any other wrapper
+type uaaTLSWrapper struct {
+	tlsConfig         *tls.Config
+	disableKeepAlives bool
+	dialTimeout       time.Duration
+	connection        uaa.Connection
+}
+
+func newUAATLSWrapper(tlsConfig *tls.Config, disableKeepAlives bool, dialTimeout time.Duration) *uaaTLSWrapper {
+	return &uaaTLSWrapper{
+		tlsConfig:         tlsConfig,
+		disableKeepAlives: disableKeepAlives,
+		dialTimeout:       dialTimeout,
+	}
+}
+
+func (w *uaaTLSWrapper) Make(request *http.Request, passedResponse *uaa.Response) error {
+	return w.connection.Make(request, passedResponse)
+}
+
+func (w *uaaTLSWrapper) Wrap(innerconnection uaa.Connection) uaa.Connection {
+	connection := uaa.NewConnection(false, w.disableKeepAlives, w.dialTimeout)
+	setTLSConfig(connection.HTTPClient, w.tlsConfig)
+	w.connection = connection
+
+	if errorWrapper, ok := innerconnection.(uaa.ConnectionWrapper); ok {
+		return errorWrapper.Wrap(connection)
+	}
+	return connection
+}
+
+// netTLSWrapper is the equivalent of ccTLSWrapper for the cfnetworking client
+type netTLSWrapper struct {
+	tlsConfig   *tls.Config
+	dialTimeout time.Duration
+	connection  cfnetworking.Connection
+}
+
+func newNetTLSWrapper(tlsConfig *tls.Config, dialTimeout time.Duration) *netTLSWrapper {
+	return &netTLSWrapper{
+		tlsConfig:   tlsConfig,
+		dialTimeout: dialTimeout,
+	}
+}
+
+func (w *netTLSWrapper) Make(request *cfnetworking.Request, passedResponse *cfnetworking.Response) error {
+	return w.connection.Make(request, passedResponse)
+}
+
+func (w *netTLSWrapper) Wrap(innerconnection cfnetworking.Connection) cfnetworking.Connection {
+	connection := cfnetworking.NewConnection(cfnetworking.Config{
+		DialTimeout: w.dialTimeout,
+	})
+	setTLSConfig(connection.HTTPClient, w.tlsConfig)
+	w.connection = connection
+
+	if errorWrapper, ok := innerconnection.(cfnetv1.ConnectionWrapper); ok {
+		return errorWrapper.Wrap(connection)
+	}
+	return connection
+}
+
+// routerTLSWrapper sets the tls configuration of the session on the connection of the router client,
+// the connection is not hidden behind any wrapper there
+type routerTLSWrapper struct {
+	tlsConfig  *tls.Config
+	connection router.Connection
+}
+
+func newRouterTLSWrapper(tlsConfig *tls.Config) *routerTLSWrapper {
+	return &routerTLSWrapper{
+		tlsConfig: tlsConfig,
+	}
+}
+
+func (w *routerTLSWrapper) Make(request *router.Request, passedResponse *router.Response) error {
+	return w.connection.Make(request, passedResponse)
+}
+
+func (w *routerTLSWrapper) Wrap(innerconnection router.Connection) router.Connection {
+	if connection, ok := innerconnection.(*router.RouterConnection); ok {
+		setTLSConfig(connection.HTTPClient, w.tlsConfig)
+	}
+	w.connection = innerconnection
+	return innerconnection
+}
@@ -0,0 +1,210 @@
+package managers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	tokenStoreLockTimeout = 1 * time.Minute
+	tokenStoreLockPoll    = 100 * time.Millisecond
+)
+
+// tokenStore keeps tokens of several foundations and users in a single file,
+// it holds a lock on the file while opened to prevent concurrent runs from racing on refresh tokens
+type tokenStore struct {
+	path       string
+	key        string
+	passphrase string
+	lock       *os.File
+}
+
+type tokenStoreFile struct {
+	Entries map[string]tokenStoreEntry `json:"entries"`
+
+	// tokens of stores written by previous versions, which only served one foundation
+	CFTokens
+}
+
+type tokenStoreEntry struct {
+	CFTokens
+
+	// tokens sealed with the passphrase of the store, the other fields are empty then
+	Salt      []byte `json:"salt,omitempty"`
+	Encrypted []byte `json:"encrypted,omitempty"`
+}
+
+// openTokenStore locks the store at the given path for the entry of user on the api endpoint,
+// tokens are encrypted when a passphrase is given
+func openTokenStore(path, endpoint, user, passphrase string) (*tokenStore, error) {
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(tokenStoreLockTimeout)
+	for {
+		locked, err := tryLockFile(lock)
+		if err != nil {
+			lock.Close()
+			return nil, err
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			lock.Close()
+			return nil, fmt.Errorf("timeout after %s waiting for lock %s held by another run", tokenStoreLockTimeout, lock.Name())
+		}
+		time.Sleep(tokenStoreLockPoll)
+	}
+	return &tokenStore{
+		path:       path,
+		key:        fmt.Sprintf("%s@%s", user, endpoint),
+		passphrase: passphrase,
+		lock:       lock,
+	}, nil
+}
+
+// Close releases the lock on the store
+func (s *tokenStore) Close() error {
+	unlockFile(s.lock)
+	return s.lock.Close()
+}
+
+// Load returns the tokens of the entry of the store, they are not set when there is none
+func (s *tokenStore) Load() (CFTokens, error) {
+	content, err := s.read()
+	if err != nil {
+		return CFTokens{}, err
+	}
+	entry, ok := content.Entries[s.key]
+	if !ok {
+		return CFTokens{}, nil
+	}
+	if len(entry.Encrypted) == 0 {
+		return entry.CFTokens, nil
+	}
+	if s.passphrase == "" {
+		return CFTokens{}, fmt.Errorf("tokens of %s are encrypted but no passphrase is set", s.key)
+	}
+
+	gcm, err := newTokenStoreCipher(s.passphrase, entry.Salt)
+	if err != nil {
+		return CFTokens{}, err
+	}
+	if len(entry.Encrypted) < gcm.NonceSize() {
+		return CFTokens{}, fmt.Errorf("tokens of %s are corrupted", s.key)
+	}
+	nonce, sealed := entry.Encrypted[:gcm.NonceSize()], entry.Encrypted[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, []byte(s.key))
+	if err != nil {
+		return CFTokens{}, fmt.Errorf("tokens of %s can't be decrypted, the passphrase may be wrong", s.key)
+	}
+	var tokens CFTokens
+	err = json.Unmarshal(plain, &tokens)
+	return tokens, err
+}
+
+// Save sets the tokens of the entry of the store, the other entries are kept as is
+func (s *tokenStore) Save(tokens CFTokens) error {
+	content, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	entry := tokenStoreEntry{CFTokens: tokens}
+	if s.passphrase != "" {
+		entry = tokenStoreEntry{Salt: make([]byte, 16)}
+		if _, err := io.ReadFull(rand.Reader, entry.Salt); err != nil {
+			return err
+		}
+		gcm, err := newTokenStoreCipher(s.passphrase, entry.Salt)
+		if err != nil {
+			return err
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return err
+		}
+		plain, _ := json.Marshal(tokens)
+		entry.Encrypted = gcm.Seal(nonce, nonce, plain, []byte(s.key))
+	}
+	content.Entries[s.key] = entry
+
+	b, err := json.MarshalIndent(tokenStoreFile{Entries: content.Entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, b)
+}
+
+func (s *tokenStore) read() (tokenStoreFile, error) {
+	content := tokenStoreFile{}
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return content, err
+	}
+	if len(b) > 0 {
+		err = json.Unmarshal(b, &content)
+		if err != nil {
+			return content, fmt.Errorf("content of %s is not a token store: %s", s.path, err)
+		}
+	}
+	if content.Entries == nil {
+		content.Entries = make(map[string]tokenStoreEntry)
+	}
+	// tokens written by previous versions belong to the foundation they were used with
+	if content.IsSet() {
+		if _, ok := content.Entries[s.key]; !ok {
+			content.Entries[s.key] = tokenStoreEntry{CFTokens: content.CFTokens}
+		}
+	}
+	return content, nil
+}
+
+// newTokenStoreCipher derives the encryption key of an entry from the passphrase and the salt of the entry
+func newTokenStoreCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeFileAtomic writes the file readable by its owner only, readers never see a partially written file
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = tmp.Chmod(0600)
+	if err == nil {
+		_, err = tmp.Write(content)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
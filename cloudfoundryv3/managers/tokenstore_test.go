@@ -0,0 +1,123 @@
+package managers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTokenStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tokens.json")
+
+	prod, err := openTokenStore(path, "https://api.prod", "admin", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens, err := prod.Load(); err != nil || tokens.IsSet() {
+		t.Fatalf("expected an empty store, got %v %v", tokens, err)
+	}
+	if err := prod.Save(CFTokens{AccessToken: "prod-access", RefreshToken: "prod-refresh"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the store is locked until closed
+	if locked, err := tryLockFileOf(path); err != nil {
+		t.Fatal(err)
+	} else if locked {
+		t.Error("expected the store to be locked while opened")
+	}
+	prod.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		t.Errorf("expected the store readable by its owner only, got %s", perm)
+	}
+
+	dev, err := openTokenStore(path, "https://api.dev", "admin", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Save(CFTokens{AccessToken: "dev-access", RefreshToken: "dev-refresh"}); err != nil {
+		t.Fatal(err)
+	}
+	dev.Close()
+
+	b, _ := ioutil.ReadFile(path)
+	if strings.Contains(string(b), "dev-refresh") {
+		t.Error("expected the tokens of the dev foundation to be encrypted")
+	}
+
+	for _, c := range []struct {
+		endpoint   string
+		passphrase string
+		refresh    string
+		fails      bool
+	}{
+		{"https://api.prod", "", "prod-refresh", false},
+		{"https://api.dev", "secret", "dev-refresh", false},
+		{"https://api.dev", "wrong", "", true},
+		{"https://api.dev", "", "", true},
+	} {
+		store, err := openTokenStore(path, c.endpoint, "admin", c.passphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens, err := store.Load()
+		store.Close()
+		if (err != nil) != c.fails {
+			t.Errorf("%s with passphrase %q: unexpected error %v", c.endpoint, c.passphrase, err)
+		}
+		if tokens.RefreshToken != c.refresh {
+			t.Errorf("%s with passphrase %q: expected refresh token %q, got %q", c.endpoint, c.passphrase, c.refresh, tokens.RefreshToken)
+		}
+	}
+}
+
+func TestTokenStoreLegacy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tokens.json")
+	if err := ioutil.WriteFile(path, []byte(`{"access_token": "access", "refresh_token": "refresh"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := openTokenStore(path, "https://api.prod", "admin", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	tokens, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.RefreshToken != "refresh" {
+		t.Errorf("expected tokens of the previous store format, got %v", tokens)
+	}
+}
+
+// tryLockFileOf tries to lock the store at path from another file descriptor, as a concurrent run would do
+func tryLockFileOf(path string) (bool, error) {
+	f, err := os.OpenFile(path+".lock", os.O_RDWR, 0600)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	locked, err := tryLockFile(f)
+	if locked {
+		unlockFile(f)
+	}
+	return locked, err
+}
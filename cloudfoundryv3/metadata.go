@@ -0,0 +1,150 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+type metadataType string
+
+type MetadataRequest struct {
+	Metadata Metadata `json:"metadata"`
+}
+
+type Metadata struct {
+	Labels      map[string]*string `json:"labels,omitempty"`
+	Annotations map[string]*string `json:"annotations,omitempty"`
+}
+
+const (
+	labelsKey      = "labels"
+	annotationsKey = "annotations"
+
+	routeMetadata               metadataType = "routes"
+	orgMetadata                 metadataType = "organizations"
+	spaceMetadata               metadataType = "spaces"
+	buildpackMetadata           metadataType = "buildpacks"
+	stackMetadata               metadataType = "stacks"
+	isolationSegmentMetadata    metadataType = "isolation_segments"
+	domainMetadata              metadataType = "domains"
+	serviceBrokerMetadata       metadataType = "service_brokers"
+	serviceOfferingMetadata     metadataType = "service_offerings"
+	servicePlanMetadata         metadataType = "service_plans"
+	routeServiceBindingMetadata metadataType = "service_route_bindings"
+)
+
+func labelsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func annotationsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func resourceToMetadata(d *schema.ResourceData) Metadata {
+	return Metadata{
+		Labels:      resourceToPayload(d, labelsKey),
+		Annotations: resourceToPayload(d, annotationsKey),
+	}
+}
+
+// resourceToPayload - create metadata update payload from resource state
+//
+//  1. construct payload as requested by "new" value
+//  2. find delete keys and create { "key" : nil } in payload
+//     ie: keys existing in "old" but not in "new"
+func resourceToPayload(d *schema.ResourceData, key string) map[string]*string {
+	res := map[string]*string{}
+	old, new := d.GetChange(key)
+	oldV := old.(map[string]interface{})
+	newV := new.(map[string]interface{})
+
+	// 1.
+	for key, val := range newV {
+		v := val.(string)
+		res[key] = &v
+	}
+
+	// 2.
+	for key := range oldV {
+		if _, ok := newV[key]; !ok {
+			res[key] = nil
+		}
+	}
+
+	return res
+}
+
+// metadataRead sets labels and annotations from the cloud controller. Only
+// the keys managed by the resource are kept, unless the resource is being
+// imported or forceRead is set (data sources)
+func metadataRead(t metadataType, d *schema.ResourceData, meta interface{}, forceRead bool) diag.Diagnostics {
+	_, hasLabels := d.GetOk(labelsKey)
+	_, hasAnnotations := d.GetOk(annotationsKey)
+	if !hasAnnotations && !hasLabels && !forceRead && !IsImportState(d) {
+		return nil
+	}
+
+	s := meta.(*managers.Session)
+	var current MetadataRequest
+	_, warns, err := rawRequest(s, "GET", pathMetadata(t, d), nil, &current)
+	diags := diagFromClient("get-"+string(t)+"-metadata", warns, err)
+	if diags.HasError() {
+		return diags
+	}
+
+	desired := resourceToMetadata(d)
+	all := forceRead || IsImportState(d)
+	_ = d.Set(labelsKey, filterMetadata(current.Metadata.Labels, desired.Labels, all))
+	_ = d.Set(annotationsKey, filterMetadata(current.Metadata.Annotations, desired.Annotations, all))
+	return diags
+}
+
+func filterMetadata(current map[string]*string, desired map[string]*string, all bool) map[string]interface{} {
+	res := make(map[string]interface{})
+	for k, v := range current {
+		if v == nil {
+			continue
+		}
+		if _, ok := desired[k]; ok || all {
+			res[k] = *v
+		}
+	}
+	return res
+}
+
+// metadataUpdate patches the labels and annotations that changed since the
+// last apply, removed keys are sent as null to be deleted
+func metadataUpdate(t metadataType, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metadata := resourceToMetadata(d)
+	if len(metadata.Labels) == 0 && len(metadata.Annotations) == 0 {
+		return nil
+	}
+
+	s := meta.(*managers.Session)
+	jobURL, warns, err := rawRequest(s, "PATCH", pathMetadata(t, d), MetadataRequest{Metadata: metadata}, nil)
+	diags := diagFromClient("update-"+string(t)+"-metadata", warns, err)
+	if diags.HasError() {
+		return diags
+	}
+	if jobURL != "" {
+		warns, err = s.ClientV3.PollJob(jobURL)
+		diags = append(diags, diagFromClient("poll-"+string(t)+"-metadata-job", warns, err)...)
+	}
+	return diags
+}
+
+func pathMetadata(t metadataType, d *schema.ResourceData) string {
+	return fmt.Sprintf("/v3/%s/%s", t, d.Id())
+}
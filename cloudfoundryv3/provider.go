@@ -33,6 +33,29 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CF_SSO_PASSCODE", ""),
 			},
+			"access_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("CF_ACCESS_TOKEN", ""),
+				ConflictsWith: []string{"jwt_bearer_assertion"},
+				Description:   "Pre-issued uaa access token (e.g.: given by cf oauth-token), it can't be renewed when it expires without refresh_token",
+			},
+			"refresh_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("CF_REFRESH_TOKEN", ""),
+				ConflictsWith: []string{"jwt_bearer_assertion"},
+				Description:   "Pre-issued uaa refresh token, used to obtain or renew the access token",
+			},
+			"jwt_bearer_assertion": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_JWT_BEARER_ASSERTION", ""),
+				Description: "JWT issued by an identity provider trusted by uaa (e.g.: a ci workload identity), exchanged for uaa tokens with the jwt bearer grant",
+			},
 			"cf_client_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -58,6 +81,27 @@ func Provider() *schema.Provider {
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CF_SKIP_SSL_VALIDATION", false),
 			},
+			"ca_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_CA_CERT", ""),
+				Description: "PEM encoded CA certificates, or path to a file containing them, to trust in addition to the system ones",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CF_CLIENT_CERT", ""),
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM encoded certificate, or path to a file containing it, presented to the endpoints for mutual TLS",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("CF_CLIENT_KEY", ""),
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM encoded private key of client_cert, or path to a file containing it",
+			},
 			"default_quota_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -82,22 +126,62 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("CF_STORE_TOKENS_PATH", ""),
 				Description: "Path to a file to store tokens used for login. (this is useful for sso, this avoid requiring each time sso passcode)",
 			},
+			"store_tokens_passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_STORE_TOKENS_PASSPHRASE", ""),
+				Description: "Passphrase to encrypt tokens written to store_tokens_path",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"cloudfoundry_domain": dataSourceDomain(),
-			"cloudfoundry_org":    dataSourceOrg(),
-			"cloudfoundry_space":  dataSourceSpace(),
+			"cloudfoundry_domain":                     dataSourceDomain(),
+			"cloudfoundry_org":                        dataSourceOrg(),
+			"cloudfoundry_space":                      dataSourceSpace(),
+			"cloudfoundry_router_group":               dataSourceRouterGroup(),
+			"cloudfoundry_org_quota":                  dataSourceOrgQuota(),
+			"cloudfoundry_space_quota":                dataSourceSpaceQuota(),
+			"cloudfoundry_stack":                      dataSourceStack(),
+			"cloudfoundry_stacks":                     dataSourceStacks(),
+			"cloudfoundry_buildpacks":                 dataSourceBuildpacks(),
+			"cloudfoundry_isolation_segment":          dataSourceIsolationSegment(),
+			"cloudfoundry_feature_flags":              dataSourceFeatureFlags(),
+			"cloudfoundry_environment_variable_group": dataSourceEnvironmentVariableGroup(),
+			"cloudfoundry_service_offering":           dataSourceServiceOffering(),
+			"cloudfoundry_service_plan":               dataSourceServicePlan(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"cloudfoundry_route":             resourceRoute(),
-			"cloudfoundry_route_destination": resourceRouteDestination(),
-			"cloudfoundry_app":               resourceApp(),
-			"cloudfoundry_droplet":           resourceDroplet(),
-			"cloudfoundry_deployment":        resourceDeployment(),
-			"cloudfoundry_service_instance":  resourceServiceInstance(),
-			"cloudfoundry_service_binding":   resourceServiceBinding(),
+			"cloudfoundry_route":                         resourceRoute(),
+			"cloudfoundry_route_destination":             resourceRouteDestination(),
+			"cloudfoundry_app":                           resourceApp(),
+			"cloudfoundry_droplet":                       resourceDroplet(),
+			"cloudfoundry_deployment":                    resourceDeployment(),
+			"cloudfoundry_service_instance":              resourceServiceInstance(),
+			"cloudfoundry_service_binding":               resourceServiceBinding(),
+			"cloudfoundry_network_policy":                resourceNetworkPolicy(),
+			"cloudfoundry_org":                           resourceOrg(),
+			"cloudfoundry_space":                         resourceSpace(),
+			"cloudfoundry_org_role":                      resourceOrgRole(),
+			"cloudfoundry_space_role":                    resourceSpaceRole(),
+			"cloudfoundry_org_roles":                     resourceOrgRoles(),
+			"cloudfoundry_space_roles":                   resourceSpaceRoles(),
+			"cloudfoundry_user":                          resourceUser(),
+			"cloudfoundry_uaa_group_membership":          resourceUAAGroupMembership(),
+			"cloudfoundry_uaa_client":                    resourceUAAClient(),
+			"cloudfoundry_org_quota":                     resourceOrgQuota(),
+			"cloudfoundry_space_quota":                   resourceSpaceQuota(),
+			"cloudfoundry_security_group":                resourceSecurityGroup(),
+			"cloudfoundry_buildpack":                     resourceBuildpack(),
+			"cloudfoundry_isolation_segment":             resourceIsolationSegment(),
+			"cloudfoundry_isolation_segment_entitlement": resourceIsolationSegmentEntitlement(),
+			"cloudfoundry_feature_flags":                 resourceFeatureFlags(),
+			"cloudfoundry_environment_variable_group":    resourceEnvironmentVariableGroup(),
+			"cloudfoundry_domain":                        resourceDomain(),
+			"cloudfoundry_service_broker":                resourceServiceBroker(),
+			"cloudfoundry_service_plan_visibility":       resourceServicePlanVisibility(),
+			"cloudfoundry_route_service_binding":         resourceRouteServiceBinding(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
@@ -106,25 +190,31 @@ func Provider() *schema.Provider {
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	c := managers.Config{
-		Endpoint:          strings.TrimSuffix(d.Get("api_url").(string), "/"),
-		User:              d.Get("user").(string),
-		Password:          d.Get("password").(string),
-		SSOPasscode:       d.Get("sso_passcode").(string),
-		CFClientID:        d.Get("cf_client_id").(string),
-		CFClientSecret:    d.Get("cf_client_secret").(string),
-		UaaClientID:       d.Get("uaa_client_id").(string),
-		UaaClientSecret:   d.Get("uaa_client_secret").(string),
-		SkipSslValidation: d.Get("skip_ssl_validation").(bool),
-		AppLogsMax:        d.Get("app_logs_max").(int),
-		DefaultQuotaName:  d.Get("default_quota_name").(string),
-		StoreTokensPath:   d.Get("store_tokens_path").(string),
+		Endpoint:              strings.TrimSuffix(d.Get("api_url").(string), "/"),
+		User:                  d.Get("user").(string),
+		Password:              d.Get("password").(string),
+		SSOPasscode:           d.Get("sso_passcode").(string),
+		AccessToken:           d.Get("access_token").(string),
+		RefreshToken:          d.Get("refresh_token").(string),
+		JWTAssertion:          d.Get("jwt_bearer_assertion").(string),
+		CFClientID:            d.Get("cf_client_id").(string),
+		CFClientSecret:        d.Get("cf_client_secret").(string),
+		UaaClientID:           d.Get("uaa_client_id").(string),
+		UaaClientSecret:       d.Get("uaa_client_secret").(string),
+		SkipSslValidation:     d.Get("skip_ssl_validation").(bool),
+		CACert:                d.Get("ca_cert").(string),
+		ClientCert:            d.Get("client_cert").(string),
+		ClientKey:             d.Get("client_key").(string),
+		AppLogsMax:            d.Get("app_logs_max").(int),
+		DefaultQuotaName:      d.Get("default_quota_name").(string),
+		StoreTokensPath:       d.Get("store_tokens_path").(string),
+		StoreTokensPassphrase: d.Get("store_tokens_passphrase").(string),
 	}
 
 	session, err := managers.NewSession(c)
 	if err != nil {
-		panic(err)
-		// return nil, diag.FromErr(err)
+		return nil, diag.FromErr(err)
 	}
 
-	return session, nil
+	return session, diagFromClient("provider-configure", session.Warnings, nil)
 }
@@ -0,0 +1,194 @@
+package cloudfoundry
+
+import (
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// unlimited is how terraform represents a limit the cloud controller
+// returns as null
+const unlimited = -1
+
+// quota is the payload of /v3/organization_quotas and /v3/space_quotas, the
+// pinned cli resources do not know log rate limits nor task limits
+type quota struct {
+	GUID     string        `json:"guid,omitempty"`
+	Name     string        `json:"name"`
+	Apps     quotaApps     `json:"apps"`
+	Services quotaServices `json:"services"`
+	Routes   quotaRoutes   `json:"routes"`
+	Domains  *quotaDomains `json:"domains,omitempty"`
+
+	Relationships struct {
+		Organization  resources.Relationship     `json:"organization"`
+		Organizations resources.RelationshipList `json:"organizations"`
+		Spaces        resources.RelationshipList `json:"spaces"`
+	} `json:"relationships"`
+}
+
+type quotaApps struct {
+	TotalMemoryInMB              *int `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         *int `json:"per_process_memory_in_mb"`
+	TotalInstances               *int `json:"total_instances"`
+	PerAppTasks                  *int `json:"per_app_tasks"`
+	LogRateLimitInBytesPerSecond *int `json:"log_rate_limit_in_bytes_per_second"`
+}
+
+type quotaServices struct {
+	PaidServicesAllowed   *bool `json:"paid_services_allowed"`
+	TotalServiceInstances *int  `json:"total_service_instances"`
+	TotalServiceKeys      *int  `json:"total_service_keys"`
+}
+
+type quotaRoutes struct {
+	TotalRoutes        *int `json:"total_routes"`
+	TotalReservedPorts *int `json:"total_reserved_ports"`
+}
+
+type quotaDomains struct {
+	TotalDomains *int `json:"total_domains"`
+}
+
+// quotaLimits lists the limits with the path of their attribute in the
+// quota payload
+var quotaLimits = []struct {
+	key       string
+	section   string
+	orgOnly   bool
+	recentAPI bool
+}{
+	{key: "total_memory_in_mb", section: "apps"},
+	{key: "per_process_memory_in_mb", section: "apps"},
+	{key: "total_instances", section: "apps"},
+	{key: "per_app_tasks", section: "apps"},
+	{key: "log_rate_limit_in_bytes_per_second", section: "apps", recentAPI: true},
+	{key: "total_service_instances", section: "services"},
+	{key: "total_service_keys", section: "services"},
+	{key: "total_routes", section: "routes"},
+	{key: "total_reserved_ports", section: "routes"},
+	{key: "total_domains", section: "domains", orgOnly: true},
+}
+
+// quotaSchema returns the limits shared by org and space quotas, set as
+// computed for data sources
+func quotaSchema(org bool, computed bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"paid_services_allowed": {
+			Type:     schema.TypeBool,
+			Optional: !computed,
+			Computed: computed,
+			Default:  defaultIf(!computed, true),
+		},
+	}
+	for _, limit := range quotaLimits {
+		if limit.orgOnly && !org {
+			continue
+		}
+		s[limit.key] = &schema.Schema{
+			Description: "-1 for unlimited",
+			Type:        schema.TypeInt,
+			Optional:    !computed,
+			Computed:    computed,
+			Default:     defaultIf(!computed, unlimited),
+		}
+		if !computed {
+			s[limit.key].ValidateFunc = validation.IntAtLeast(unlimited)
+		}
+	}
+	return s
+}
+
+func defaultIf(cond bool, v interface{}) interface{} {
+	if cond {
+		return v
+	}
+	return nil
+}
+
+// quotaPayload returns the limits to send, unlimited is sent as null. The log
+// rate limit is only sent once used so that quotas still work with cloud
+// controllers which do not know it
+func quotaPayload(d *schema.ResourceData, org bool) map[string]interface{} {
+	payload := map[string]interface{}{
+		"name": d.Get("name").(string),
+		"services": map[string]interface{}{
+			"paid_services_allowed": d.Get("paid_services_allowed").(bool),
+		},
+	}
+	for _, limit := range quotaLimits {
+		if limit.orgOnly && !org {
+			continue
+		}
+		v := d.Get(limit.key).(int)
+		if limit.recentAPI && v == unlimited && (d.IsNewResource() || !d.HasChange(limit.key)) {
+			continue
+		}
+		section, ok := payload[limit.section].(map[string]interface{})
+		if !ok {
+			section = map[string]interface{}{}
+			payload[limit.section] = section
+		}
+		if v == unlimited {
+			section[limit.key] = nil
+		} else {
+			section[limit.key] = v
+		}
+	}
+	return payload
+}
+
+// quotaToResource sets the limits from the cloud controller, null becomes
+// unlimited
+func quotaToResource(q quota, d *schema.ResourceData, org bool) {
+	_ = d.Set("name", q.Name)
+	if q.Services.PaidServicesAllowed != nil {
+		_ = d.Set("paid_services_allowed", *q.Services.PaidServicesAllowed)
+	}
+
+	values := map[string]*int{
+		"total_memory_in_mb":                 q.Apps.TotalMemoryInMB,
+		"per_process_memory_in_mb":           q.Apps.PerProcessMemoryInMB,
+		"total_instances":                    q.Apps.TotalInstances,
+		"per_app_tasks":                      q.Apps.PerAppTasks,
+		"log_rate_limit_in_bytes_per_second": q.Apps.LogRateLimitInBytesPerSecond,
+		"total_service_instances":            q.Services.TotalServiceInstances,
+		"total_service_keys":                 q.Services.TotalServiceKeys,
+		"total_routes":                       q.Routes.TotalRoutes,
+		"total_reserved_ports":               q.Routes.TotalReservedPorts,
+	}
+	if org && q.Domains != nil {
+		values["total_domains"] = q.Domains.TotalDomains
+	}
+	for key, v := range values {
+		if v == nil {
+			_ = d.Set(key, unlimited)
+		} else {
+			_ = d.Set(key, *v)
+		}
+	}
+}
+
+// quotaChanged reports a change of name or limits, relationships excepted
+func quotaChanged(d *schema.ResourceData, org bool) bool {
+	keys := []string{"name", "paid_services_allowed"}
+	for _, limit := range quotaLimits {
+		if limit.orgOnly && !org {
+			continue
+		}
+		keys = append(keys, limit.key)
+	}
+	return d.HasChanges(keys...)
+}
+
+// managedGUIDs keeps the managed assignments which still exist, assignments
+// made outside of the resource are left untouched
+func managedGUIDs(managed *schema.Set, current []string) *schema.Set {
+	res := schema.NewSet(schema.HashString, nil)
+	for _, guid := range current {
+		if managed.Contains(guid) {
+			res.Add(guid)
+		}
+	}
+	return res
+}
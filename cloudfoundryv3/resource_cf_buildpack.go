@@ -0,0 +1,326 @@
+package cloudfoundry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/resources"
+	"code.cloudfoundry.org/cli/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceBuildpack() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "admin buildpack, the name and stack pair is unique so a buildpack can be managed once per stack",
+
+		CreateContext: resourceBuildpackCreate,
+		ReadContext:   resourceBuildpackRead,
+		UpdateContext: resourceBuildpackUpdate,
+		DeleteContext: resourceBuildpackDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceBuildpackRead),
+		},
+
+		CustomizeDiff: buildpackUploadDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"stack": {
+				Description: "Name of the stack the buildpack runs on, e.g. cflinuxfs4, the buildpack applies to any stack when not set",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"position": {
+				Description:  "Order of the buildpack during auto-detection, starting at 1, appended last when not set",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"enabled": {
+				Description: "Allows apps to stage with the buildpack",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+
+			"locked": {
+				Description: "Prevents updates of the buildpack bits, the provider unlocks the buildpack during its own uploads",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"path": {
+				Description:  "Path to a local zip of the buildpack",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"source_code_hash": {
+				Description: "SHA256 of the uploaded zip, the zip is only uploaded again when it changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"filename": {
+				Description: "Name of the uploaded zip as known by the cloud controller",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+// buildpackUploadDiff plans an upload when the zip content changes or when
+// the bits on the cloud controller are not the ones of the zip anymore
+func buildpackUploadDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("path") {
+		return d.SetNewComputed("source_code_hash")
+	}
+
+	path := d.Get("path").(string)
+	hash, err := fileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("path: %s", err)
+	}
+
+	upload := d.Get("source_code_hash").(string) != hash
+	if d.Id() != "" {
+		upload = upload ||
+			d.Get("filename").(string) != filepath.Base(path) ||
+			d.Get("state").(string) != constant.BuildpackReady
+	}
+	if !upload {
+		return nil
+	}
+
+	if err := d.SetNew("source_code_hash", hash); err != nil {
+		return err
+	}
+	if d.Id() != "" {
+		// a hash unchanged in state would hide the upload from the plan
+		if err := d.SetNewComputed("filename"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("state")
+	}
+	return nil
+}
+
+func resourceBuildpackCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	// the bits of a locked buildpack cannot be uploaded, it is locked once
+	// uploaded
+	bp := buildpackFromResource(d)
+	bp.Locked = types.NullBool{}
+	bp, warns, err := session.ClientV3.CreateBuildpack(bp)
+	diags = append(diags, diagFromClient("create-buildpack", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(bp.GUID)
+
+	diags = append(diags, uploadBuildpack(ctx, session, d, d.Timeout(schema.TimeoutCreate))...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.Get("locked").(bool) {
+		_, warns, err = session.ClientV3.UpdateBuildpack(resources.Buildpack{
+			GUID:   d.Id(),
+			Locked: types.NullBool{IsSet: true, Value: true},
+		})
+		diags = append(diags, diagFromClient("lock-buildpack", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(buildpackMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceBuildpackRead(ctx, d, meta)...)
+}
+
+func resourceBuildpackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	bps, warns, err := session.ClientV3.GetBuildpacks(
+		ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{d.Id()}},
+	)
+	diags = append(diags, diagFromClient("get-buildpack", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(bps) == 0 {
+		d.SetId("")
+		return diags
+	}
+	bp := bps[0]
+
+	_ = d.Set("name", bp.Name)
+	_ = d.Set("stack", bp.Stack)
+	_ = d.Set("position", bp.Position.Value)
+	_ = d.Set("enabled", bp.Enabled.Value)
+	_ = d.Set("locked", bp.Locked.Value)
+	_ = d.Set("filename", bp.Filename)
+	_ = d.Set("state", bp.State)
+
+	return append(diags, metadataRead(buildpackMetadata, d, meta, false)...)
+}
+
+func resourceBuildpackUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if d.HasChanges("source_code_hash", "filename", "state") {
+		if o, _ := d.GetChange("locked"); o.(bool) {
+			_, warns, err := session.ClientV3.UpdateBuildpack(resources.Buildpack{
+				GUID:   d.Id(),
+				Locked: types.NullBool{IsSet: true, Value: false},
+			})
+			diags = append(diags, diagFromClient("unlock-buildpack", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		diags = append(diags, uploadBuildpack(ctx, session, d, d.Timeout(schema.TimeoutUpdate))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChanges("name", "position", "enabled", "locked", "source_code_hash", "filename", "state") {
+		bp := buildpackFromResource(d)
+		bp.GUID = d.Id()
+		bp.Stack = ""
+		_, warns, err := session.ClientV3.UpdateBuildpack(bp)
+		diags = append(diags, diagFromClient("update-buildpack", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(buildpackMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceBuildpackRead(ctx, d, meta)...)
+}
+
+func resourceBuildpackDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	jobURL, warns, err := session.ClientV3.DeleteBuildpack(d.Id())
+	if IsErrNotFound(err) {
+		return diags
+	}
+	diags = append(diags, diagFromClient("delete-buildpack", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutDelete))...)
+}
+
+func buildpackFromResource(d *schema.ResourceData) resources.Buildpack {
+	bp := resources.Buildpack{
+		Name:    d.Get("name").(string),
+		Stack:   d.Get("stack").(string),
+		Enabled: types.NullBool{IsSet: true, Value: d.Get("enabled").(bool)},
+		Locked:  types.NullBool{IsSet: true, Value: d.Get("locked").(bool)},
+	}
+	if position, ok := d.GetOk("position"); ok {
+		bp.Position = types.NullInt{IsSet: true, Value: position.(int)}
+	}
+	return bp
+}
+
+// uploadBuildpack uploads the zip and waits for the cloud controller to
+// process it
+func uploadBuildpack(ctx context.Context, session *managers.Session, d *schema.ResourceData, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	path := d.Get("path").(string)
+	zip, err := os.Open(path)
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "failed to read zip file for path: " + path,
+			Detail:   err.Error(),
+		})
+	}
+	defer zip.Close()
+	zipInfo, err := zip.Stat()
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "failed to stat zip file for path: " + path,
+			Detail:   err.Error(),
+		})
+	}
+
+	jobURL, warns, err := session.ClientV3.UploadBuildpack(d.Id(), path, zip, zipInfo.Size())
+	diags = append(diags, diagFromClient("upload-buildpack", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, waitJob(ctx, session, jobURL, timeout)...)
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
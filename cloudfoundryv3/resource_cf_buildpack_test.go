@@ -0,0 +1,112 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResBuildpack_normal(t *testing.T) {
+
+	path := testAccEnv.AssetPath("buildpacks", "tomee-buildpack-v4.5.2.zip")
+
+	src := `
+		resource "cloudfoundry_buildpack" "tomee" {
+			name = "tf-acc-tomee-buildpack"
+			stack = "cflinuxfs3"
+			path = %q
+			position = %d
+			enabled = %t
+			locked = true
+		}
+	`
+
+	ref := "cloudfoundry_buildpack.tomee"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckBuildpackDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, path, 1, false),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckBuildpackExists(ref),
+						resource.TestCheckResourceAttr(ref, "stack", "cflinuxfs3"),
+						resource.TestCheckResourceAttr(ref, "position", "1"),
+						resource.TestCheckResourceAttr(ref, "enabled", "false"),
+						resource.TestCheckResourceAttr(ref, "locked", "true"),
+						resource.TestCheckResourceAttr(ref, "state", "READY"),
+						resource.TestCheckResourceAttr(ref, "filename", "tomee-buildpack-v4.5.2.zip"),
+						resource.TestCheckResourceAttrSet(ref, "source_code_hash"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, path, 2, true),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckBuildpackExists(ref),
+						resource.TestCheckResourceAttr(ref, "position", "2"),
+						resource.TestCheckResourceAttr(ref, "enabled", "true"),
+						resource.TestCheckResourceAttr(ref, "locked", "true"),
+					),
+				},
+				{
+					ResourceName:            ref,
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"path", "source_code_hash"},
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckBuildpackExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("buildpack '%s' not found in terraform state", resource)
+		}
+
+		bps, _, err := testAccEnv.Session.ClientV3.GetBuildpacks(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(bps) != 1 {
+			return fmt.Errorf("buildpack %s not found", rs.Primary.ID)
+		}
+		if bps[0].Filename != rs.Primary.Attributes["filename"] {
+			return fmt.Errorf("expected filename %s, got %s", rs.Primary.Attributes["filename"], bps[0].Filename)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckBuildpackDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		bps, _, err := testAccEnv.Session.ClientV3.GetBuildpacks(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(bps) > 0 {
+			return fmt.Errorf("buildpack %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,226 @@
+package cloudfoundry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/resources"
+	"code.cloudfoundry.org/cli/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceDomain() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "shared, private, internal or tcp domain, private domains can be shared with other organizations",
+
+		CreateContext: resourceDomainCreate,
+		ReadContext:   resourceDomainRead,
+		UpdateContext: resourceDomainUpdate,
+		DeleteContext: resourceDomainDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceDomainRead),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Description:  "Full name of the domain, or set sub_domain and domain",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "sub_domain"},
+			},
+
+			"sub_domain": {
+				Description:  "First label of the domain name",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"domain"},
+			},
+
+			"domain": {
+				Description:  "Domain name without its first label",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"sub_domain"},
+			},
+
+			"internal": {
+				Description:   "Internal domains are only used for container-to-container networking",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ForceNew:      true,
+				ConflictsWith: []string{"org", "router_group"},
+			},
+
+			"org": {
+				Description:   "GUID of the organization owning the private domain, the domain is shared with all organizations when not set",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"router_group"},
+			},
+
+			"router_group": {
+				Description: "GUID of the router group of a tcp domain, see the cloudfoundry_router_group data source",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"shared_orgs": {
+				Description:  "GUIDs of the organizations the private domain is shared with, besides its owner",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Set:          schema.HashString,
+				RequiredWith: []string{"org"},
+			},
+
+			"supported_protocols": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name = d.Get("sub_domain").(string) + "." + d.Get("domain").(string)
+	}
+
+	domain, warns, err := session.ClientV3.CreateDomain(resources.Domain{
+		Name:             name,
+		Internal:         types.NullBool{IsSet: true, Value: d.Get("internal").(bool)},
+		OrganizationGUID: d.Get("org").(string),
+		RouterGroup:      d.Get("router_group").(string),
+	})
+	diags = append(diags, diagFromClient("create-domain", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(domain.GUID)
+
+	if orgs := setToStrings(d.Get("shared_orgs").(*schema.Set)); len(orgs) > 0 {
+		warns, err = session.ClientV3.SharePrivateDomainToOrgs(d.Id(), ccv3.SharedOrgs{GUIDs: orgs})
+		diags = append(diags, diagFromClient("share-domain", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(domainMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceDomainRead(ctx, d, meta)...)
+}
+
+func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	domain, warns, err := session.ClientV3.GetDomain(d.Id())
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-domain", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	domainParts := strings.SplitN(domain.Name, ".", 2)
+	_ = d.Set("name", domain.Name)
+	_ = d.Set("sub_domain", domainParts[0])
+	if len(domainParts) > 1 {
+		_ = d.Set("domain", domainParts[1])
+	}
+	_ = d.Set("internal", domain.Internal.Value)
+	_ = d.Set("org", domain.OrganizationGUID)
+	_ = d.Set("router_group", domain.RouterGroup)
+	_ = d.Set("supported_protocols", domain.Protocols)
+
+	if domain.OrganizationGUID != "" {
+		var shared resources.RelationshipList
+		_, warns, err = rawRequest(session, "GET", "/v3/domains/"+d.Id()+"/relationships/shared_organizations", nil, &shared)
+		diags = append(diags, diagFromClient("get-domain-shared-orgs", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		_ = d.Set("shared_orgs", shared.GUIDs)
+	}
+
+	return append(diags, metadataRead(domainMetadata, d, meta, false)...)
+}
+
+func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if d.HasChange("shared_orgs") {
+		o, n := d.GetChange("shared_orgs")
+		for _, org := range setToStrings(o.(*schema.Set).Difference(n.(*schema.Set))) {
+			warns, err := session.ClientV3.UnsharePrivateDomainFromOrg(d.Id(), org)
+			if IsErrNotFound(err) {
+				continue
+			}
+			diags = append(diags, diagFromClient("unshare-domain", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		if added := setToStrings(n.(*schema.Set).Difference(o.(*schema.Set))); len(added) > 0 {
+			warns, err := session.ClientV3.SharePrivateDomainToOrgs(d.Id(), ccv3.SharedOrgs{GUIDs: added})
+			diags = append(diags, diagFromClient("share-domain", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	diags = append(diags, metadataUpdate(domainMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceDomainRead(ctx, d, meta)...)
+}
+
+func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	jobURL, warns, err := session.ClientV3.DeleteDomain(d.Id())
+	if IsErrNotFound(err) {
+		return diags
+	}
+	diags = append(diags, diagFromClient("delete-domain", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutDelete))...)
+}
@@ -0,0 +1,142 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResDomain_private(t *testing.T) {
+
+	org := testAccEnv.Organization
+
+	src := `
+		resource "cloudfoundry_org" "shared" {
+			name = "tf-acc-domain-shared"
+		}
+		resource "cloudfoundry_domain" "private" {
+			sub_domain = "tf-acc-private"
+			domain = %q
+			org = %q
+			shared_orgs = [ %s ]
+			labels = {
+				env = %q
+			}
+		}
+		data "cloudfoundry_domain" "private" {
+			sub_domain = "tf-acc-private"
+			domain = %q
+			depends_on = [ cloudfoundry_domain.private ]
+		}
+	`
+
+	ref := "cloudfoundry_domain.private"
+	refData := "data.cloudfoundry_domain.private"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckDomainDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, testAccEnv.Domain.Name, org.GUID, "cloudfoundry_org.shared.id", "dev", testAccEnv.Domain.Name),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckDomainExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-private."+testAccEnv.Domain.Name),
+						resource.TestCheckResourceAttr(ref, "internal", "false"),
+						resource.TestCheckResourceAttr(ref, "shared_orgs.#", "1"),
+						resource.TestCheckResourceAttr(ref, "labels.env", "dev"),
+						resource.TestCheckResourceAttrPair(refData, "id", ref, "id"),
+						resource.TestCheckResourceAttr(refData, "org", org.GUID),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, testAccEnv.Domain.Name, org.GUID, "", "prod", testAccEnv.Domain.Name),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckDomainExists(ref),
+						resource.TestCheckResourceAttr(ref, "shared_orgs.#", "0"),
+						resource.TestCheckResourceAttr(ref, "labels.env", "prod"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func TestAccResDomain_internal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_domain" "internal" {
+			name = "tf-acc.apps.internal"
+			internal = true
+		}
+	`
+
+	ref := "cloudfoundry_domain.internal"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckDomainDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: src,
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckDomainExists(ref),
+						resource.TestCheckResourceAttr(ref, "sub_domain", "tf-acc"),
+						resource.TestCheckResourceAttr(ref, "domain", "apps.internal"),
+						resource.TestCheckResourceAttr(ref, "internal", "true"),
+						resource.TestCheckResourceAttr(ref, "org", ""),
+					),
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckDomainExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("domain '%s' not found in terraform state", resource)
+		}
+
+		domain, _, err := testAccEnv.Session.ClientV3.GetDomain(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if domain.Name != rs.Primary.Attributes["name"] {
+			return fmt.Errorf("expected name %s, got %s", rs.Primary.Attributes["name"], domain.Name)
+		}
+		if domain.OrganizationGUID != rs.Primary.Attributes["org"] {
+			return fmt.Errorf("expected org %s, got %s", rs.Primary.Attributes["org"], domain.OrganizationGUID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDomainDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		_, _, err := testAccEnv.Session.ClientV3.GetDomain(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("domain %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
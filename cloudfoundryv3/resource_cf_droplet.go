@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
@@ -25,6 +26,8 @@ func resourceDroplet() *schema.Resource {
 		ReadContext:   resourceDropletRead,
 		DeleteContext: resourceDropletDelete,
 
+		CustomizeDiff: dropletBuildpacksDiff,
+
 		Schema: map[string]*schema.Schema{
 
 			"app_id": {
@@ -140,6 +143,83 @@ func resourceDroplet() *schema.Resource {
 	}
 }
 
+// dropletBuildpacksDiff checks at plan time that the stack exists and that
+// every buildpack given by name is available and enabled on that stack,
+// buildpacks given by URL are left to staging
+func dropletBuildpacksDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("type").(string) != string(constant.AppLifecycleTypeBuildpack) {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("stack") && !d.HasChange("buildpacks") {
+		return nil
+	}
+	if !d.NewValueKnown("stack") || !d.NewValueKnown("buildpacks") {
+		return nil
+	}
+	s := m.(*managers.Session)
+
+	stack := d.Get("stack").(string)
+	stacks, _, err := s.ClientV3.GetStacks()
+	if err != nil {
+		return fmt.Errorf("get-stacks: %s", err)
+	}
+	names := make([]string, 0, len(stacks))
+	found := false
+	for _, st := range stacks {
+		names = append(names, st.Name)
+		found = found || st.Name == stack
+	}
+	if !found {
+		return fmt.Errorf("stack %q does not exist, available stacks: %s", stack, strings.Join(names, ", "))
+	}
+
+	for i, v := range d.Get("buildpacks").([]interface{}) {
+		name, _ := v.(string)
+		if !d.NewValueKnown(fmt.Sprintf("buildpacks.%d", i)) || name == "" || strings.Contains(name, "://") {
+			continue
+		}
+		bps, _, err := s.ClientV3.GetBuildpacks(
+			ccv3.Query{Key: ccv3.NameFilter, Values: []string{name}},
+		)
+		if err != nil {
+			return fmt.Errorf("get-buildpacks: %s", err)
+		}
+		if err := buildpackStackCheck(name, stack, bps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildpackStackCheck looks for an enabled buildpack of the stack, an exact
+// stack match first and then a buildpack without stack which applies to any
+// stack, disabled copies are only reported when no enabled one is found
+func buildpackStackCheck(name, stack string, bps []resources.Buildpack) error {
+	if len(bps) == 0 {
+		return fmt.Errorf("buildpack %q does not exist", name)
+	}
+	disabled := false
+	for _, candidateStack := range []string{stack, ""} {
+		for _, bp := range bps {
+			if bp.Stack != candidateStack {
+				continue
+			}
+			if bp.Enabled.Value {
+				return nil
+			}
+			disabled = true
+		}
+	}
+	if disabled {
+		return fmt.Errorf("buildpack %q is disabled on stack %q", name, stack)
+	}
+	stacks := make([]string, 0, len(bps))
+	for _, bp := range bps {
+		stacks = append(stacks, bp.Stack)
+	}
+	return fmt.Errorf("buildpack %q is not available on stack %q, only on: %s", name, stack, strings.Join(stacks, ", "))
+}
+
 func resourceDropletCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	s := m.(*managers.Session)
 	lifecycleType := constant.AppLifecycleType(d.Get("type").(string))
@@ -0,0 +1,40 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResDroplet_invalidBuildpack(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_droplet" "invalid" {
+			app_id           = "not-created"
+			buildpacks       = [%q]
+			stack            = %q
+			source_code_path = %q
+		}
+	`
+	path := testAccEnv.AssetPath("dummy-app.zip")
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(src, "binary_buildpack", "tf-acc-no-such-stack", path),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`stack "tf-acc-no-such-stack" does not exist`),
+				},
+				{
+					Config:      fmt.Sprintf(src, "tf-acc-no-such-buildpack", "cflinuxfs3", path),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`buildpack "tf-acc-no-such-buildpack" does not exist`),
+				},
+			},
+		})
+}
@@ -0,0 +1,125 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/resources"
+	"code.cloudfoundry.org/cli/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+var environmentVariableGroups = []string{
+	string(constant.RunningEnvironmentVariableGroup),
+	string(constant.StagingEnvironmentVariableGroup),
+}
+
+func resourceEnvironmentVariableGroup() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "variables of the running or staging environment variable group, only the declared variables are managed",
+
+		CreateContext: resourceEnvironmentVariableGroupUpdate,
+		ReadContext:   resourceEnvironmentVariableGroupRead,
+		UpdateContext: resourceEnvironmentVariableGroupUpdate,
+		DeleteContext: resourceEnvironmentVariableGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnvironmentVariableGroupImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Description:  "Name of the group, one of: running or staging",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(environmentVariableGroups, false),
+			},
+
+			"variables": {
+				Type:      schema.TypeMap,
+				Required:  true,
+				Sensitive: true,
+				ValidateFunc: validation.All(
+					validateEnvMapKeysPattern,
+					validateEnvMapEmptyStrings,
+				),
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceEnvironmentVariableGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("name", d.Id())
+	return ImportReadContext(resourceEnvironmentVariableGroupRead)(ctx, d, meta)
+}
+
+func resourceEnvironmentVariableGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	name := d.Get("name").(string)
+	vars, warns, err := session.ClientV3.GetEnvironmentVariableGroup(constant.EnvironmentVariableGroupName(name))
+	diags = append(diags, diagFromClient("get-environment-variable-group", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	managed := d.Get("variables").(map[string]interface{})
+	tfVars := make(map[string]interface{})
+	for key, v := range vars {
+		if _, ok := managed[key]; ok || IsImportState(d) {
+			tfVars[key] = v.Value
+		}
+	}
+
+	d.SetId(name)
+	_ = d.Set("variables", tfVars)
+
+	return diags
+}
+
+func resourceEnvironmentVariableGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	// the group is patched, unset values remove the removed variables and
+	// variables not declared are left untouched
+	o, n := d.GetChange("variables")
+	vars := resources.EnvironmentVariables{}
+	for key := range o.(map[string]interface{}) {
+		vars[key] = types.FilteredString{}
+	}
+	for key, v := range n.(map[string]interface{}) {
+		vars[key] = types.FilteredString{IsSet: true, Value: v.(string)}
+	}
+
+	name := d.Get("name").(string)
+	_, warns, err := session.ClientV3.UpdateEnvironmentVariableGroup(constant.EnvironmentVariableGroupName(name), vars)
+	diags = append(diags, diagFromClient("update-environment-variable-group", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(name)
+
+	return append(diags, resourceEnvironmentVariableGroupRead(ctx, d, meta)...)
+}
+
+func resourceEnvironmentVariableGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	vars := resources.EnvironmentVariables{}
+	for key := range d.Get("variables").(map[string]interface{}) {
+		vars[key] = types.FilteredString{}
+	}
+	if len(vars) == 0 {
+		return diags
+	}
+
+	_, warns, err := session.ClientV3.UpdateEnvironmentVariableGroup(constant.EnvironmentVariableGroupName(d.Id()), vars)
+	return append(diags, diagFromClient("update-environment-variable-group", warns, err)...)
+}
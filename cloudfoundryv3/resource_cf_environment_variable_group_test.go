@@ -0,0 +1,75 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResEnvironmentVariableGroup_normal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_environment_variable_group" "running" {
+			name = "running"
+			variables = {
+				%s
+			}
+		}
+		data "cloudfoundry_environment_variable_group" "running" {
+			name = "running"
+			depends_on = [ cloudfoundry_environment_variable_group.running ]
+		}
+	`
+
+	ref := "cloudfoundry_environment_variable_group.running"
+	refData := "data.cloudfoundry_environment_variable_group.running"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckEnvironmentVariableGroupVar("TF_ACC_ONE", ""),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, `TF_ACC_ONE = "1"
+						TF_ACC_TWO = "2"`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckEnvironmentVariableGroupVar("TF_ACC_ONE", "1"),
+						resource.TestCheckResourceAttr(ref, "variables.%", "2"),
+						resource.TestCheckResourceAttr(refData, "variables.TF_ACC_TWO", "2"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, `TF_ACC_ONE = "one"`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckEnvironmentVariableGroupVar("TF_ACC_ONE", "one"),
+						testAccCheckEnvironmentVariableGroupVar("TF_ACC_TWO", ""),
+						resource.TestCheckResourceAttr(ref, "variables.%", "1"),
+					),
+				},
+			},
+		},
+	)
+}
+
+// testAccCheckEnvironmentVariableGroupVar checks a running group variable,
+// an empty value checks its absence
+func testAccCheckEnvironmentVariableGroupVar(key, value string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		vars, _, err := testAccEnv.Session.ClientV3.GetEnvironmentVariableGroup(constant.RunningEnvironmentVariableGroup)
+		if err != nil {
+			return err
+		}
+		v, ok := vars[key]
+		if value == "" && ok {
+			return fmt.Errorf("expected variable %s to be unset, got %s", key, v.Value)
+		}
+		if value != "" && v.Value != value {
+			return fmt.Errorf("expected variable %s to be %s, got %s", key, value, v.Value)
+		}
+		return nil
+	}
+}
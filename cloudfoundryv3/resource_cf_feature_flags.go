@@ -0,0 +1,142 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+const featureFlagsID = "feature_flags"
+
+// featureFlag is the payload of /v3/feature_flags, the pinned cli resource
+// does not know custom error messages
+type featureFlag struct {
+	Name               string  `json:"name,omitempty"`
+	Enabled            bool    `json:"enabled"`
+	CustomErrorMessage *string `json:"custom_error_message"`
+}
+
+func resourceFeatureFlags() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "platform feature flags, only the declared flags are managed and removed flags keep their current value",
+
+		CreateContext: resourceFeatureFlagsUpdate,
+		ReadContext:   resourceFeatureFlagsRead,
+		UpdateContext: resourceFeatureFlagsUpdate,
+		DeleteContext: resourceFeatureFlagsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceFeatureFlagsRead),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"flag": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						"name": {
+							Description:  "Name of the flag, e.g. diego_docker",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"custom_error_message": {
+							Description: "Message returned to users when the flag prevents an action",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceFeatureFlagsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	flags, diags := getFeatureFlags(session)
+	if diags.HasError() {
+		return diags
+	}
+
+	managed := make(map[string]bool)
+	for _, f := range d.Get("flag").(*schema.Set).List() {
+		managed[f.(map[string]interface{})["name"].(string)] = true
+	}
+
+	tfFlags := make([]interface{}, 0, len(managed))
+	for _, flag := range flags {
+		if !managed[flag.Name] && !IsImportState(d) {
+			continue
+		}
+		tfFlags = append(tfFlags, featureFlagToResource(flag))
+	}
+
+	d.SetId(featureFlagsID)
+	_ = d.Set("flag", tfFlags)
+
+	return diags
+}
+
+func resourceFeatureFlagsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	o, n := d.GetChange("flag")
+	for _, f := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+		tfFlag := f.(map[string]interface{})
+		flag := featureFlag{Enabled: tfFlag["enabled"].(bool)}
+		// a null message resets the platform default one
+		if msg := tfFlag["custom_error_message"].(string); msg != "" {
+			flag.CustomErrorMessage = &msg
+		}
+
+		name := tfFlag["name"].(string)
+		_, warns, err := rawRequest(session, "PATCH", "/v3/feature_flags/"+name, flag, nil)
+		diags = append(diags, diagFromClient("update-feature-flag-"+name, warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceFeatureFlagsRead(ctx, d, meta)...)
+}
+
+func resourceFeatureFlagsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	// flags cannot be removed and their defaults are not known, they keep
+	// their current value
+	return diags
+}
+
+func getFeatureFlags(session *managers.Session) ([]featureFlag, diag.Diagnostics) {
+	var list struct {
+		Resources []featureFlag `json:"resources"`
+	}
+	_, warns, err := rawRequest(session, "GET", "/v3/feature_flags?per_page=5000", nil, &list)
+	return list.Resources, diagFromClient("get-feature-flags", warns, err)
+}
+
+func featureFlagToResource(flag featureFlag) map[string]interface{} {
+	msg := ""
+	if flag.CustomErrorMessage != nil {
+		msg = *flag.CustomErrorMessage
+	}
+	return map[string]interface{}{
+		"name":                 flag.Name,
+		"enabled":              flag.Enabled,
+		"custom_error_message": msg,
+	}
+}
@@ -0,0 +1,67 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResFeatureFlags_normal(t *testing.T) {
+
+	// the flag is left disabled, its platform default
+	src := `
+		resource "cloudfoundry_feature_flags" "ff" {
+			flag {
+				name = "hide_marketplace_from_unauthenticated_users"
+				enabled = %t
+				custom_error_message = %q
+			}
+		}
+		data "cloudfoundry_feature_flags" "ff" {
+			depends_on = [ cloudfoundry_feature_flags.ff ]
+		}
+	`
+
+	ref := "cloudfoundry_feature_flags.ff"
+	refData := "data.cloudfoundry_feature_flags.ff"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, true, "ask the platform team"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckFeatureFlag("hide_marketplace_from_unauthenticated_users", true),
+						resource.TestCheckResourceAttr(ref, "flag.#", "1"),
+						resource.TestCheckResourceAttr(refData, "enabled.hide_marketplace_from_unauthenticated_users", "true"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, false, ""),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckFeatureFlag("hide_marketplace_from_unauthenticated_users", false),
+						resource.TestCheckResourceAttr(ref, "flag.#", "1"),
+						resource.TestCheckResourceAttr(refData, "enabled.hide_marketplace_from_unauthenticated_users", "false"),
+					),
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckFeatureFlag(name string, enabled bool) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		flag, _, err := testAccEnv.Session.ClientV3.GetFeatureFlag(name)
+		if err != nil {
+			return err
+		}
+		if flag.Enabled != enabled {
+			return fmt.Errorf("expected feature flag %s enabled to be %t", name, enabled)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,151 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceIsolationSegment() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "isolation segment, the name must match the placement tag of the dedicated diego cells",
+
+		CreateContext: resourceIsolationSegmentCreate,
+		ReadContext:   resourceIsolationSegmentRead,
+		UpdateContext: resourceIsolationSegmentUpdate,
+		DeleteContext: resourceIsolationSegmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceIsolationSegmentRead),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func resourceIsolationSegmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	segment, warns, err := session.ClientV3.CreateIsolationSegment(resources.IsolationSegment{
+		Name: d.Get("name").(string),
+	})
+	diags = append(diags, diagFromClient("create-isolation-segment", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(segment.GUID)
+
+	diags = append(diags, metadataUpdate(isolationSegmentMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceIsolationSegmentRead(ctx, d, meta)...)
+}
+
+func resourceIsolationSegmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	segment, warns, err := session.ClientV3.GetIsolationSegment(d.Id())
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-isolation-segment", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	_ = d.Set("name", segment.Name)
+
+	return append(diags, metadataRead(isolationSegmentMetadata, d, meta, false)...)
+}
+
+func resourceIsolationSegmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	// the pinned client cannot rename a segment
+	if d.HasChange("name") {
+		payload := map[string]interface{}{"name": d.Get("name").(string)}
+		_, warns, err := rawRequest(session, "PATCH", "/v3/isolation_segments/"+d.Id(), payload, nil)
+		diags = append(diags, diagFromClient("update-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(isolationSegmentMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceIsolationSegmentRead(ctx, d, meta)...)
+}
+
+func resourceIsolationSegmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	warns, err := session.ClientV3.DeleteIsolationSegment(d.Id())
+	if IsErrNotFound(err) {
+		return diags
+	}
+	return append(diags, diagFromClient("delete-isolation-segment", warns, err)...)
+}
+
+// appsToRestart warns about the started apps of the spaces, they keep
+// running on their former cells until restarted
+func appsToRestart(session *managers.Session, reason string, spaceGUIDs ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(spaceGUIDs) == 0 {
+		return diags
+	}
+
+	apps, warns, err := session.ClientV3.GetApplications(
+		ccv3.Query{Key: ccv3.SpaceGUIDFilter, Values: spaceGUIDs},
+	)
+	diags = append(diags, diagFromClient("get-applications", warns, err)...)
+	if diags.HasError() {
+		// the change itself succeeded, listing the apps is best effort
+		for i := range diags {
+			diags[i].Severity = diag.Warning
+		}
+		return diags
+	}
+
+	names := make([]string, 0)
+	for _, app := range apps {
+		if app.State == constant.ApplicationStarted {
+			names = append(names, app.Name)
+		}
+	}
+	if len(names) == 0 {
+		return diags
+	}
+	sort.Strings(names)
+
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%d started apps must be restarted", len(names)),
+		Detail:   fmt.Sprintf("%s, these apps run on their former cells until restarted: %s", reason, strings.Join(names, ", ")),
+	})
+}
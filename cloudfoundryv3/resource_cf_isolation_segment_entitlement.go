@@ -0,0 +1,251 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceIsolationSegmentEntitlement() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "organizations entitled to an isolation segment, organizations entitled outside of terraform are left untouched",
+
+		CreateContext: resourceIsolationSegmentEntitlementCreate,
+		ReadContext:   resourceIsolationSegmentEntitlementRead,
+		UpdateContext: resourceIsolationSegmentEntitlementUpdate,
+		DeleteContext: resourceIsolationSegmentEntitlementDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceIsolationSegmentEntitlementRead),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"segment": {
+				Description:  "GUID of the isolation segment",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"orgs": {
+				Description: "GUIDs of the organizations entitled to the segment",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"default": {
+				Description: "Make the segment the default of the organizations, their spaces without isolation segment then run on it",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceIsolationSegmentEntitlementCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	segment := d.Get("segment").(string)
+	orgs := setToStrings(d.Get("orgs").(*schema.Set))
+
+	_, warns, err := session.ClientV3.EntitleIsolationSegmentToOrganizations(segment, orgs)
+	diags = append(diags, diagFromClient("entitle-isolation-segment", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(segment)
+
+	if d.Get("default").(bool) {
+		diags = append(diags, setOrgsDefaultSegment(session, segment, orgs, true)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceIsolationSegmentEntitlementRead(ctx, d, meta)...)
+}
+
+func resourceIsolationSegmentEntitlementRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	entitled, warns, err := session.ClientV3.GetIsolationSegmentOrganizations(d.Id())
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-isolation-segment-orgs", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	guids := make([]string, 0, len(entitled))
+	for _, org := range entitled {
+		guids = append(guids, org.GUID)
+	}
+	orgs := schema.NewSet(schema.HashString, nil)
+	if IsImportState(d) {
+		for _, guid := range guids {
+			orgs.Add(guid)
+		}
+	} else {
+		orgs = managedGUIDs(d.Get("orgs").(*schema.Set), guids)
+	}
+
+	// the segment is the default when it is the default of every managed
+	// organization
+	isDefault := orgs.Len() > 0
+	for _, org := range setToStrings(orgs) {
+		rel, warns, err := session.ClientV3.GetOrganizationDefaultIsolationSegment(org)
+		diags = append(diags, diagFromClient("get-org-default-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		isDefault = isDefault && rel.GUID == d.Id()
+	}
+
+	_ = d.Set("segment", d.Id())
+	_ = d.Set("orgs", orgs)
+	_ = d.Set("default", isDefault)
+
+	return diags
+}
+
+func resourceIsolationSegmentEntitlementUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	segment := d.Id()
+
+	o, n := d.GetChange("orgs")
+	removed := setToStrings(o.(*schema.Set).Difference(n.(*schema.Set)))
+	added := setToStrings(n.(*schema.Set).Difference(o.(*schema.Set)))
+
+	diags = append(diags, revokeIsolationSegment(session, segment, removed)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(added) > 0 {
+		_, warns, err := session.ClientV3.EntitleIsolationSegmentToOrganizations(segment, added)
+		diags = append(diags, diagFromClient("entitle-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// default is read as false as soon as a managed organization differs,
+	// setting it again is a no-op for the others
+	if d.HasChange("default") || len(added) > 0 {
+		orgs := setToStrings(n.(*schema.Set))
+		if !d.HasChange("default") {
+			orgs = added
+		}
+		diags = append(diags, setOrgsDefaultSegment(session, segment, orgs, d.Get("default").(bool))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceIsolationSegmentEntitlementRead(ctx, d, meta)...)
+}
+
+func resourceIsolationSegmentEntitlementDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	return revokeIsolationSegment(session, d.Id(), setToStrings(d.Get("orgs").(*schema.Set)))
+}
+
+// revokeIsolationSegment removes the entitlement of the organizations, the
+// segment must not be their default anymore
+func revokeIsolationSegment(session *managers.Session, segment string, orgs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(orgs) == 0 {
+		return diags
+	}
+
+	diags = append(diags, setOrgsDefaultSegment(session, segment, orgs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	for _, org := range orgs {
+		warns, err := session.ClientV3.DeleteIsolationSegmentOrganization(segment, org)
+		if IsErrNotFound(err) {
+			continue
+		}
+		diags = append(diags, diagFromClient("revoke-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
+
+// setOrgsDefaultSegment makes the segment the default of the organizations,
+// or resets their default when it is the segment. The started apps of the
+// spaces following the organization default are reported for restart
+func setOrgsDefaultSegment(session *managers.Session, segment string, orgs []string, isDefault bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, org := range orgs {
+		rel, warns, err := session.ClientV3.GetOrganizationDefaultIsolationSegment(org)
+		if IsErrNotFound(err) {
+			continue
+		}
+		diags = append(diags, diagFromClient("get-org-default-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		if (rel.GUID == segment) == isDefault {
+			continue
+		}
+
+		target := ""
+		if isDefault {
+			target = segment
+		}
+		_, warns, err = session.ClientV3.UpdateOrganizationDefaultIsolationSegmentRelationship(org, target)
+		diags = append(diags, diagFromClient("update-org-default-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		spaces, spaceDiags := spacesFollowingOrgDefault(session, org)
+		diags = append(diags, spaceDiags...)
+		diags = append(diags, appsToRestart(session, fmt.Sprintf("the default isolation segment of organization %s changed", org), spaces...)...)
+	}
+	return diags
+}
+
+// spacesFollowingOrgDefault lists the spaces of the organization without
+// isolation segment of their own, failures are only warnings
+func spacesFollowingOrgDefault(session *managers.Session, org string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	spaces, _, warns, err := session.ClientV3.GetSpaces(
+		ccv3.Query{Key: ccv3.OrganizationGUIDFilter, Values: []string{org}},
+	)
+	if err != nil {
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "could not list the spaces whose apps must be restarted",
+			Detail:   err.Error(),
+		})
+	}
+	diags = append(diags, diagFromClient("get-spaces", warns, nil)...)
+
+	guids := make([]string, 0, len(spaces))
+	for _, space := range spaces {
+		rel, _, err := session.ClientV3.GetSpaceIsolationSegment(space.GUID)
+		if err == nil && rel.GUID == "" {
+			guids = append(guids, space.GUID)
+		}
+	}
+	return guids, diags
+}
@@ -0,0 +1,137 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResIsolationSegment_normal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_org" "seg" {
+			name = "tf-acc-isolation-segment"
+		}
+		resource "cloudfoundry_isolation_segment" "seg" {
+			name = %q
+			labels = {
+				purpose = "regulated"
+			}
+		}
+		resource "cloudfoundry_isolation_segment_entitlement" "seg" {
+			segment = cloudfoundry_isolation_segment.seg.id
+			orgs = [ cloudfoundry_org.seg.id ]
+			default = %t
+		}
+		data "cloudfoundry_isolation_segment" "seg" {
+			name = cloudfoundry_isolation_segment.seg.name
+			depends_on = [ cloudfoundry_isolation_segment_entitlement.seg ]
+		}
+	`
+
+	ref := "cloudfoundry_isolation_segment.seg"
+	refEntitlement := "cloudfoundry_isolation_segment_entitlement.seg"
+	refData := "data.cloudfoundry_isolation_segment.seg"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckIsolationSegmentDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, "tf-acc-segment", false),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckIsolationSegmentExists(ref),
+						resource.TestCheckResourceAttr(ref, "labels.purpose", "regulated"),
+						resource.TestCheckResourceAttr(refEntitlement, "orgs.#", "1"),
+						resource.TestCheckResourceAttr(refEntitlement, "default", "false"),
+						resource.TestCheckResourceAttrPair(refData, "id", ref, "id"),
+						resource.TestCheckResourceAttr(refData, "orgs.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, "tf-acc-segment-renamed", true),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckIsolationSegmentExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-segment-renamed"),
+						resource.TestCheckResourceAttr(refEntitlement, "default", "true"),
+						testAccCheckOrgDefaultSegment("cloudfoundry_org.seg", ref),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					ResourceName:      refEntitlement,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckIsolationSegmentExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("isolation segment '%s' not found in terraform state", resource)
+		}
+
+		segment, _, err := testAccEnv.Session.ClientV3.GetIsolationSegment(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if segment.Name != rs.Primary.Attributes["name"] {
+			return fmt.Errorf("expected name %s, got %s", rs.Primary.Attributes["name"], segment.Name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckOrgDefaultSegment(org, segment string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rsOrg, ok := s.RootModule().Resources[org]
+		if !ok {
+			return fmt.Errorf("org '%s' not found in terraform state", org)
+		}
+		rsSegment, ok := s.RootModule().Resources[segment]
+		if !ok {
+			return fmt.Errorf("isolation segment '%s' not found in terraform state", segment)
+		}
+
+		rel, _, err := testAccEnv.Session.ClientV3.GetOrganizationDefaultIsolationSegment(rsOrg.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if rel.GUID != rsSegment.Primary.ID {
+			return fmt.Errorf("expected default isolation segment %s, got %s", rsSegment.Primary.ID, rel.GUID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckIsolationSegmentDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		_, _, err := testAccEnv.Session.ClientV3.GetIsolationSegment(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("isolation segment %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
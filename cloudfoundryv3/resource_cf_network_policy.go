@@ -0,0 +1,251 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cfnetworking-cli-api/cfnetworking/cfnetv1"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceNetworkPolicy() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "container-to-container network policies allowing source apps to reach destination apps, usually on an internal domain",
+
+		CreateContext: resourceNetworkPolicyCreate,
+		ReadContext:   resourceNetworkPolicyRead,
+		UpdateContext: resourceNetworkPolicyUpdate,
+		DeleteContext: resourceNetworkPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"policy": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Set:      networkPolicyHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						"source_app": {
+							Description:  "GUID of the app sending traffic",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+
+						"destination_app": {
+							Description:  "GUID of the app receiving traffic",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+
+						"port": {
+							Description:      "Port (8080) or port range (8080-8090) the destination app listens on",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validatePortRange,
+							DiffSuppressFunc: portRangeDiffSuppress,
+						},
+
+						"protocol": {
+							Description:  "Protocol allowed, one of: tcp or udp",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "tcp",
+							ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// networkPolicyKey identifies a policy, ports are normalized so that 8080
+// and 8080-8080 are seen as the same policy
+func networkPolicyKey(source, destination, protocol string, start, end int) string {
+	return fmt.Sprintf("%s-%s-%s-%d-%d", source, destination, protocol, start, end)
+}
+
+// portRangeDiffSuppress ignores a port rewritten as an equivalent range, set
+// elements keep the same hash but their port attribute is still compared
+func portRangeDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	oldStart, oldEnd, err := portRangeParse(old)
+	if err != nil {
+		return false
+	}
+	newStart, newEnd, err := portRangeParse(new)
+	return err == nil && oldStart == newStart && oldEnd == newEnd
+}
+
+func networkPolicyHash(v interface{}) int {
+	elem := v.(map[string]interface{})
+	start, end, _ := portRangeParse(elem["port"].(string))
+	return schema.HashString(networkPolicyKey(
+		elem["source_app"].(string),
+		elem["destination_app"].(string),
+		elem["protocol"].(string),
+		start,
+		end,
+	))
+}
+
+func resourceNetworkPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	guid, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies := networkPoliciesFromSet(d.Get("policy").(*schema.Set))
+	err = session.NetClient.CreatePolicies(policies)
+	diags = append(diags, diagFromClient("create-network-policies", nil, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(guid)
+	return append(diags, resourceNetworkPolicyRead(ctx, d, meta)...)
+}
+
+func resourceNetworkPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	policiesTf := d.Get("policy").(*schema.Set)
+
+	existing, errs := listNetworkPolicies(session, policiesTf)
+	diags = append(diags, errs...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// keep the policies from state which still exist, the ones removed out
+	// of band will be re-created on next apply
+	final := schema.NewSet(networkPolicyHash, []interface{}{})
+	for _, p := range policiesTf.List() {
+		elem := p.(map[string]interface{})
+		start, end, _ := portRangeParse(elem["port"].(string))
+		key := networkPolicyKey(
+			elem["source_app"].(string),
+			elem["destination_app"].(string),
+			elem["protocol"].(string),
+			start,
+			end,
+		)
+		if existing[key] {
+			final.Add(elem)
+		}
+	}
+	_ = d.Set("policy", final)
+
+	return diags
+}
+
+func resourceNetworkPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	// sets are compared by hash which uses normalized ports, a port rewritten
+	// as an equivalent range only changes the port attribute of the element,
+	// whose diff is suppressed
+	o, n := d.GetChange("policy")
+	remove := o.(*schema.Set).Difference(n.(*schema.Set))
+	add := n.(*schema.Set).Difference(o.(*schema.Set))
+
+	if remove.Len() > 0 {
+		err := session.NetClient.RemovePolicies(networkPoliciesFromSet(remove))
+		diags = append(diags, diagFromClient("remove-network-policies", nil, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if add.Len() > 0 {
+		err := session.NetClient.CreatePolicies(networkPoliciesFromSet(add))
+		diags = append(diags, diagFromClient("create-network-policies", nil, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceNetworkPolicyRead(ctx, d, meta)...)
+}
+
+func resourceNetworkPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	policies := networkPoliciesFromSet(d.Get("policy").(*schema.Set))
+	err := session.NetClient.RemovePolicies(policies)
+	diags = append(diags, diagFromClient("remove-network-policies", nil, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+// listNetworkPolicies returns the keys of the policies existing for the apps
+// referenced in the given set
+func listNetworkPolicies(session *managers.Session, policiesTf *schema.Set) (_ map[string]bool, diags diag.Diagnostics) {
+	existing := make(map[string]bool)
+
+	idsMap := make(map[string]bool)
+	for _, p := range policiesTf.List() {
+		elem := p.(map[string]interface{})
+		idsMap[elem["source_app"].(string)] = true
+		idsMap[elem["destination_app"].(string)] = true
+	}
+	if len(idsMap) == 0 {
+		return existing, diags
+	}
+	ids := make([]string, 0, len(idsMap))
+	for id := range idsMap {
+		ids = append(ids, id)
+	}
+
+	policies, err := session.NetClient.ListPolicies(ids...)
+	diags = append(diags, diagFromClient("list-network-policies", nil, err)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, policy := range policies {
+		existing[networkPolicyKey(
+			policy.Source.ID,
+			policy.Destination.ID,
+			string(policy.Destination.Protocol),
+			policy.Destination.Ports.Start,
+			policy.Destination.Ports.End,
+		)] = true
+	}
+
+	return existing, diags
+}
+
+func networkPoliciesFromSet(set *schema.Set) []cfnetv1.Policy {
+	policies := make([]cfnetv1.Policy, 0, set.Len())
+	for _, p := range set.List() {
+		elem := p.(map[string]interface{})
+		// already checked by validatePortRange
+		start, end, _ := portRangeParse(elem["port"].(string))
+		policies = append(policies, cfnetv1.Policy{
+			Source: cfnetv1.PolicySource{
+				ID: elem["source_app"].(string),
+			},
+			Destination: cfnetv1.PolicyDestination{
+				ID:       elem["destination_app"].(string),
+				Protocol: cfnetv1.PolicyProtocol(elem["protocol"].(string)),
+				Ports: cfnetv1.Ports{
+					Start: start,
+					End:   end,
+				},
+			},
+		})
+	}
+	return policies
+}
@@ -0,0 +1,88 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResNetworkPolicy_normal(t *testing.T) {
+	space := testAccEnv.Space
+
+	src := `
+		resource "cloudfoundry_app" "front" {
+			name = "net-policy-front"
+			space_id = %q
+		}
+
+		resource "cloudfoundry_app" "back" {
+			name = "net-policy-back"
+			space_id = %q
+		}
+
+		resource "cloudfoundry_network_policy" "policy" {
+			policy {
+				source_app = cloudfoundry_app.front.id
+				destination_app = cloudfoundry_app.back.id
+				port = %q
+			}
+			%s
+		}
+	`
+	udp := `
+			policy {
+				source_app = cloudfoundry_app.front.id
+				destination_app = cloudfoundry_app.back.id
+				port = "9000-9010"
+				protocol = "udp"
+			}
+	`
+
+	ref := "cloudfoundry_network_policy.policy"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     testAccPreCheck(t),
+		Providers:    testAccProviders,
+		CheckDestroy: appCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(src, space.GUID, space.GUID, "8080", udp),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ref, "policy.#", "2"),
+					networkPolicyCheckCount("cloudfoundry_app.front", 2),
+				),
+			},
+			// an equivalent port range must not produce a diff
+			{
+				Config:   fmt.Sprintf(src, space.GUID, space.GUID, "8080-8080", udp),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(src, space.GUID, space.GUID, "8081", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(ref, "policy.#", "1"),
+					networkPolicyCheckCount("cloudfoundry_app.front", 1),
+				),
+			},
+		},
+	})
+}
+
+func networkPolicyCheckCount(appRef string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[appRef]
+		if !ok {
+			return fmt.Errorf("app '%s' not found in terraform state", appRef)
+		}
+
+		policies, err := testAccEnv.Session.NetClient.ListPolicies(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if len(policies) != expected {
+			return fmt.Errorf("expected %d network policies for %s, found %d", expected, appRef, len(policies))
+		}
+		return nil
+	}
+}
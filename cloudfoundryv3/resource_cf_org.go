@@ -0,0 +1,140 @@
+package cloudfoundry
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceOrg() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "organization, renaming is done in place",
+
+		CreateContext: resourceOrgCreate,
+		ReadContext:   resourceOrgRead,
+		UpdateContext: resourceOrgUpdate,
+		DeleteContext: resourceOrgDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceOrgRead),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"quota": {
+				Description: "GUID of the organization quota, the platform default quota is assigned when not set",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func resourceOrgCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	org, warns, err := session.ClientV3.CreateOrganization(d.Get("name").(string))
+	diags = append(diags, diagFromClient("create-org", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(org.GUID)
+
+	if quota, ok := d.GetOk("quota"); ok && quota.(string) != org.QuotaGUID {
+		_, warns, err = session.ClientV3.ApplyOrganizationQuota(quota.(string), org.GUID)
+		diags = append(diags, diagFromClient("apply-org-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(orgMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceOrgRead(ctx, d, meta)...)
+}
+
+func resourceOrgRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	org, warns, err := session.ClientV3.GetOrganization(d.Id())
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-org", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	_ = d.Set("name", org.Name)
+	_ = d.Set("quota", org.QuotaGUID)
+
+	return append(diags, metadataRead(orgMetadata, d, meta, false)...)
+}
+
+func resourceOrgUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if d.HasChange("name") {
+		_, warns, err := session.ClientV3.UpdateOrganization(resources.Organization{
+			GUID: d.Id(),
+			Name: d.Get("name").(string),
+		})
+		diags = append(diags, diagFromClient("update-org", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// an organization always has a quota, removing it from the config
+	// leaves the current one in place
+	if d.HasChange("quota") && d.Get("quota").(string) != "" {
+		_, warns, err := session.ClientV3.ApplyOrganizationQuota(d.Get("quota").(string), d.Id())
+		diags = append(diags, diagFromClient("apply-org-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(orgMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceOrgRead(ctx, d, meta)...)
+}
+
+func resourceOrgDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	jobURL, warns, err := session.ClientV3.DeleteOrganization(d.Id())
+	diags = append(diags, diagFromClient("delete-org", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutDelete))...)
+}
@@ -0,0 +1,168 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceOrgQuota() *schema.Resource {
+
+	s := quotaSchema(true, false)
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.NoZeroValues,
+	}
+	s["orgs"] = &schema.Schema{
+		Description: "GUIDs of the organizations the quota is applied to, removed organizations get the default quota back",
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+	}
+
+	return &schema.Resource{
+		Description: "organization quota, limits are unlimited when set to -1",
+
+		CreateContext: resourceOrgQuotaCreate,
+		ReadContext:   resourceOrgQuotaRead,
+		UpdateContext: resourceOrgQuotaUpdate,
+		DeleteContext: resourceOrgQuotaDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceOrgQuotaRead),
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceOrgQuotaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	var q quota
+	_, warns, err := rawRequest(session, "POST", "/v3/organization_quotas", quotaPayload(d, true), &q)
+	diags = append(diags, diagFromClient("create-org-quota", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(q.GUID)
+
+	if orgs := setToStrings(d.Get("orgs").(*schema.Set)); len(orgs) > 0 {
+		diags = append(diags, applyOrgQuota(session, d.Id(), orgs)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceOrgQuotaRead(ctx, d, meta)...)
+}
+
+func resourceOrgQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	var q quota
+	_, warns, err := rawRequest(session, "GET", "/v3/organization_quotas/"+d.Id(), nil, &q)
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-org-quota", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	quotaToResource(q, d, true)
+	_ = d.Set("orgs", managedGUIDs(d.Get("orgs").(*schema.Set), q.Relationships.Organizations.GUIDs))
+
+	return diags
+}
+
+func resourceOrgQuotaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if quotaChanged(d, true) {
+		_, warns, err := rawRequest(session, "PATCH", "/v3/organization_quotas/"+d.Id(), quotaPayload(d, true), nil)
+		diags = append(diags, diagFromClient("update-org-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("orgs") {
+		o, n := d.GetChange("orgs")
+		added := setToStrings(n.(*schema.Set).Difference(o.(*schema.Set)))
+		removed := setToStrings(o.(*schema.Set).Difference(n.(*schema.Set)))
+
+		if len(added) > 0 {
+			diags = append(diags, applyOrgQuota(session, d.Id(), added)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		if len(removed) > 0 {
+			diags = append(diags, unapplyOrgQuota(session, removed)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return append(diags, resourceOrgQuotaRead(ctx, d, meta)...)
+}
+
+func resourceOrgQuotaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	// a quota in use cannot be deleted
+	if orgs := setToStrings(d.Get("orgs").(*schema.Set)); len(orgs) > 0 {
+		diags = append(diags, unapplyOrgQuota(session, orgs)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	jobURL, warns, err := session.ClientV3.DeleteOrganizationQuota(d.Id())
+	diags = append(diags, diagFromClient("delete-org-quota", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	warns, err = session.ClientV3.PollJob(jobURL)
+	return append(diags, diagFromClient("poll-delete-org-quota-job", warns, err)...)
+}
+
+func applyOrgQuota(session *managers.Session, quotaGUID string, orgs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, org := range orgs {
+		_, warns, err := session.ClientV3.ApplyOrganizationQuota(quotaGUID, org)
+		diags = append(diags, diagFromClient("apply-org-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
+
+// unapplyOrgQuota gives the default quota back to the organizations, an
+// organization always has a quota
+func unapplyOrgQuota(session *managers.Session, orgs []string) diag.Diagnostics {
+	name := session.Config.DefaultQuotaName
+	quotas, warns, err := session.ClientV3.GetOrganizationQuotas(
+		ccv3.Query{Key: ccv3.NameFilter, Values: []string{name}},
+	)
+	diags := diagFromClient("get-default-org-quota", warns, err)
+	if diags.HasError() {
+		return diags
+	}
+	if len(quotas) == 0 {
+		return append(diags, diag.FromErr(fmt.Errorf("default organization quota %s not found, see the default_quota_name provider attribute", name))...)
+	}
+
+	return append(diags, applyOrgQuota(session, quotas[0].GUID, orgs)...)
+}
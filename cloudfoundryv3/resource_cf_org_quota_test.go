@@ -0,0 +1,106 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResOrgQuota_normal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_org" "quota" {
+			name = "tf-acc-org-quota"
+		}
+		resource "cloudfoundry_org_quota" "q1" {
+			name = "tf-acc-org-quota"
+			total_memory_in_mb = %d
+			per_process_memory_in_mb = 1024
+			total_instances = 20
+			log_rate_limit_in_bytes_per_second = %d
+			paid_services_allowed = false
+			orgs = [ %s ]
+		}
+	`
+
+	ref := "cloudfoundry_org_quota.q1"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckOrgQuotaDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, 2048, 1024, "cloudfoundry_org.quota.id"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckOrgQuotaExists(ref),
+						resource.TestCheckResourceAttr(ref, "total_memory_in_mb", "2048"),
+						resource.TestCheckResourceAttr(ref, "log_rate_limit_in_bytes_per_second", "1024"),
+						resource.TestCheckResourceAttr(ref, "total_routes", "-1"),
+						resource.TestCheckResourceAttr(ref, "paid_services_allowed", "false"),
+						resource.TestCheckResourceAttr(ref, "orgs.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, 4096, -1, ""),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckOrgQuotaExists(ref),
+						resource.TestCheckResourceAttr(ref, "total_memory_in_mb", "4096"),
+						resource.TestCheckResourceAttr(ref, "log_rate_limit_in_bytes_per_second", "-1"),
+						resource.TestCheckResourceAttr(ref, "orgs.#", "0"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckOrgQuotaExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("org quota '%s' not found in terraform state", resource)
+		}
+
+		q, _, err := testAccEnv.Session.ClientV3.GetOrganizationQuota(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if fmt.Sprint(q.Apps.TotalMemory.Value) != rs.Primary.Attributes["total_memory_in_mb"] {
+			return fmt.Errorf("expected total memory %s, got %d", rs.Primary.Attributes["total_memory_in_mb"], q.Apps.TotalMemory.Value)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckOrgQuotaDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		quotas, _, err := testAccEnv.Session.ClientV3.GetOrganizationQuotas(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(quotas) > 0 {
+			return fmt.Errorf("org quota %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResOrg_normal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_org" "org1" {
+			name = %q
+			labels = {
+				env = %q
+			}
+		}
+	`
+
+	ref := "cloudfoundry_org.org1"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckOrgDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, "tf-acc-org", "dev"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckOrgExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-org"),
+						resource.TestCheckResourceAttr(ref, "labels.env", "dev"),
+						resource.TestCheckResourceAttrSet(ref, "quota"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, "tf-acc-org-renamed", "prod"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckOrgExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-org-renamed"),
+						resource.TestCheckResourceAttr(ref, "labels.env", "prod"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckOrgExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("org '%s' not found in terraform state", resource)
+		}
+
+		org, _, err := testAccEnv.Session.ClientV3.GetOrganization(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if org.Name != rs.Primary.Attributes["name"] {
+			return fmt.Errorf("expected org name %s, got %s", rs.Primary.Attributes["name"], org.Name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckOrgDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		orgs, _, err := testAccEnv.Session.ClientV3.GetOrganizations(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(orgs) > 0 {
+			return fmt.Errorf("org %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,208 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+const (
+	defaultUserOrigin = "uaa"
+
+	// not known by the pinned cli version
+	spaceSupporterRole constant.RoleType = "space_supporter"
+)
+
+// roleScope describes where roles apply, either an organization or a space
+type roleScope struct {
+	name        string
+	attr        string
+	queryFilter ccv3.QueryKey
+	types       []string
+}
+
+var orgRoleScope = roleScope{
+	name:        "org",
+	attr:        "org",
+	queryFilter: ccv3.OrganizationGUIDFilter,
+	types: []string{
+		string(constant.OrgUserRole),
+		string(constant.OrgAuditorRole),
+		string(constant.OrgManagerRole),
+		string(constant.OrgBillingManagerRole),
+	},
+}
+
+var spaceRoleScope = roleScope{
+	name:        "space",
+	attr:        "space",
+	queryFilter: ccv3.SpaceGUIDFilter,
+	types: []string{
+		string(constant.SpaceDeveloperRole),
+		string(constant.SpaceAuditorRole),
+		string(constant.SpaceManagerRole),
+		string(spaceSupporterRole),
+	},
+}
+
+// setOn returns the role for the given scope guid
+func (r roleScope) setOn(role resources.Role, guid string) resources.Role {
+	if r.name == orgRoleScope.name {
+		role.OrgGUID = guid
+	} else {
+		role.SpaceGUID = guid
+	}
+	return role
+}
+
+// guidOf returns the scope guid the role applies to
+func (r roleScope) guidOf(role resources.Role) string {
+	if r.name == orgRoleScope.name {
+		return role.OrgGUID
+	}
+	return role.SpaceGUID
+}
+
+func resourceOrgRole() *schema.Resource {
+	return resourceRole(orgRoleScope)
+}
+
+func resourceSpaceRole() *schema.Resource {
+	return resourceRole(spaceRoleScope)
+}
+
+func resourceRole(scope roleScope) *schema.Resource {
+
+	return &schema.Resource{
+		Description: fmt.Sprintf("role given to a single user on the %s", scope.name),
+
+		CreateContext: resourceRoleCreate(scope),
+		ReadContext:   resourceRoleRead(scope),
+		DeleteContext: resourceRoleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceRoleRead(scope)),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(scope.types, false),
+			},
+
+			scope.attr: {
+				Description: fmt.Sprintf("GUID of the %s the role applies to", scope.name),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"user": {
+				Description:  "GUID of the user",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user", "username"},
+			},
+
+			"username": {
+				Description: "Name of the user, resolved within origin",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+
+			"origin": {
+				Description:   "Identity provider of the user when set by username, uaa by default",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"user"},
+			},
+		},
+	}
+}
+
+func resourceRoleCreate(scope roleScope) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+		session := meta.(*managers.Session)
+
+		role := scope.setOn(resources.Role{
+			Type: constant.RoleType(d.Get("type").(string)),
+		}, d.Get(scope.attr).(string))
+
+		if username, ok := d.GetOk("username"); ok {
+			role.Username = username.(string)
+			role.Origin = defaultUserOrigin
+			if origin, ok := d.GetOk("origin"); ok {
+				role.Origin = origin.(string)
+			}
+		} else {
+			role.UserGUID = d.Get("user").(string)
+		}
+
+		role, warns, err := session.ClientV3.CreateRole(role)
+		diags = append(diags, diagFromClient("create-"+scope.name+"-role", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		d.SetId(role.GUID)
+
+		return append(diags, resourceRoleRead(scope)(ctx, d, meta)...)
+	}
+}
+
+func resourceRoleRead(scope roleScope) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+		session := meta.(*managers.Session)
+
+		roles, _, warns, err := session.ClientV3.GetRoles(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{d.Id()}},
+		)
+		diags = append(diags, diagFromClient("get-"+scope.name+"-role", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		if len(roles) == 0 {
+			d.SetId("")
+			return diags
+		}
+		role := roles[0]
+
+		// an org role imported as a space role, or the other way around
+		if scope.guidOf(role) == "" {
+			return append(diags, diag.Errorf("role %s is not a %s role", role.GUID, scope.name)...)
+		}
+
+		user, warns, err := session.ClientV3.GetUser(role.UserGUID)
+		diags = append(diags, diagFromClient("get-user", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		_ = d.Set("type", string(role.Type))
+		_ = d.Set(scope.attr, scope.guidOf(role))
+		_ = d.Set("user", role.UserGUID)
+		_ = d.Set("username", user.Username)
+		_ = d.Set("origin", user.Origin)
+
+		return diags
+	}
+}
+
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteRole(meta.(*managers.Session), d.Id())
+}
@@ -0,0 +1,134 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResRole_normal(t *testing.T) {
+
+	username := os.Getenv("CF_USER")
+
+	src := `
+		resource "cloudfoundry_org" "roles" {
+			name = "tf-acc-roles"
+		}
+		resource "cloudfoundry_space" "roles" {
+			name = "tf-acc-roles"
+			org = cloudfoundry_org.roles.id
+		}
+		resource "cloudfoundry_org_role" "user" {
+			type = "organization_user"
+			org = cloudfoundry_org.roles.id
+			username = %q
+		}
+		resource "cloudfoundry_space_role" "auditor" {
+			type = "space_auditor"
+			space = cloudfoundry_space.roles.id
+			user = cloudfoundry_org_role.user.user
+		}
+		resource "cloudfoundry_space_roles" "managers" {
+			type = "space_manager"
+			space = cloudfoundry_space.roles.id
+			users = [ cloudfoundry_org_role.user.user ]
+		}
+		resource "cloudfoundry_space_roles" "developers" {
+			type = "space_developer"
+			space = cloudfoundry_space.roles.id
+			usernames {
+				username = cloudfoundry_org_role.user.username
+			}
+		}
+	`
+
+	refOrg := "cloudfoundry_org_role.user"
+	refSpace := "cloudfoundry_space_role.auditor"
+	refSpaces := "cloudfoundry_space_roles.managers"
+	refNames := "cloudfoundry_space_roles.developers"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			CheckDestroy: resource.ComposeTestCheckFunc(
+				testAccCheckRoleDestroyed(refOrg),
+				testAccCheckRoleDestroyed(refSpace),
+			),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, username),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRoleExists(refOrg),
+						testAccCheckRoleExists(refSpace),
+						resource.TestCheckResourceAttr(refOrg, "username", username),
+						resource.TestCheckResourceAttr(refOrg, "origin", "uaa"),
+						resource.TestCheckResourceAttrPair(refSpace, "username", refOrg, "username"),
+						resource.TestCheckResourceAttr(refSpaces, "users.#", "1"),
+						resource.TestCheckResourceAttr(refNames, "users.#", "0"),
+						resource.TestCheckResourceAttr(refNames, "usernames.#", "1"),
+					),
+				},
+				{
+					ResourceName:      refSpace,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config:   fmt.Sprintf(src, username),
+					PlanOnly: true,
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckRoleExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("role '%s' not found in terraform state", resource)
+		}
+
+		roles, _, _, err := testAccEnv.Session.ClientV3.GetRoles(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(roles) != 1 {
+			return fmt.Errorf("role %s not found", rs.Primary.ID)
+		}
+		if string(roles[0].Type) != rs.Primary.Attributes["type"] {
+			return fmt.Errorf("expected role type %s, got %s", rs.Primary.Attributes["type"], roles[0].Type)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRoleDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		roles, _, _, err := testAccEnv.Session.ClientV3.GetRoles(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(roles) > 0 {
+			return fmt.Errorf("role %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
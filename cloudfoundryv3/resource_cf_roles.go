@@ -0,0 +1,286 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceOrgRoles() *schema.Resource {
+	return resourceRoles(orgRoleScope)
+}
+
+func resourceSpaceRoles() *schema.Resource {
+	return resourceRoles(spaceRoleScope)
+}
+
+// resourceRoles is authoritative over one role type of an org or a space:
+// roles given to users which are not listed are removed
+func resourceRoles(scope roleScope) *schema.Resource {
+
+	return &schema.Resource{
+		Description: fmt.Sprintf("authoritative list of users having a role on the %s", scope.name),
+
+		CreateContext: resourceRolesCreate(scope),
+		ReadContext:   resourceRolesRead(scope),
+		UpdateContext: resourceRolesUpdate(scope),
+		DeleteContext: resourceRolesDelete(scope),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRolesImport(scope),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(scope.types, false),
+			},
+
+			scope.attr: {
+				Description: fmt.Sprintf("GUID of the %s the roles apply to", scope.name),
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"users": {
+				Description:  "GUIDs of the users having the role",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Set:          schema.HashString,
+				AtLeastOneOf: []string{"users", "usernames"},
+			},
+
+			"usernames": {
+				Description: "Users having the role given by name, resolved within their origin",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"origin": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  defaultUserOrigin,
+						},
+					},
+				},
+				AtLeastOneOf: []string{"users", "usernames"},
+			},
+		},
+	}
+}
+
+func resourceRolesImport(scope roleScope) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.SplitN(d.Id(), "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected import id <%s-guid>/<role-type>, got %s", scope.name, d.Id())
+		}
+		_ = d.Set(scope.attr, parts[0])
+		_ = d.Set("type", parts[1])
+		return ImportReadContext(resourceRolesRead(scope))(ctx, d, meta)
+	}
+}
+
+func resourceRolesCreate(scope roleScope) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+		d.SetId(fmt.Sprintf("%s/%s", d.Get(scope.attr).(string), d.Get("type").(string)))
+
+		diags = append(diags, reconcileRoles(scope, d, meta)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		return append(diags, resourceRolesRead(scope)(ctx, d, meta)...)
+	}
+}
+
+func resourceRolesRead(scope roleScope) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+		roles, roleUsers, diags := listRoles(scope, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+
+		// users keep the form they are listed with, the others are set by
+		// guid to be removed
+		guids := d.Get("users").(*schema.Set)
+		names := roleUsernames(d)
+		users := make([]interface{}, 0, len(roles))
+		usernames := make([]interface{}, 0)
+		for _, role := range roles {
+			user := roleUsers[role.UserGUID]
+			if name, ok := names[usernameKey(user.Username, user.Origin)]; ok && !guids.Contains(role.UserGUID) {
+				usernames = append(usernames, name)
+				continue
+			}
+			users = append(users, role.UserGUID)
+		}
+		_ = d.Set("users", schema.NewSet(schema.HashString, users))
+		_ = d.Set("usernames", usernames)
+
+		return diags
+	}
+}
+
+func resourceRolesUpdate(scope roleScope) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+		diags = append(diags, reconcileRoles(scope, d, meta)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		return append(diags, resourceRolesRead(scope)(ctx, d, meta)...)
+	}
+}
+
+func resourceRolesDelete(scope roleScope) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+		roles, roleUsers, diags := listRoles(scope, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+
+		// only the roles known by the state are removed on destroy, the
+		// ones given meanwhile by someone else are left
+		guids := d.Get("users").(*schema.Set)
+		names := roleUsernames(d)
+		for _, role := range roles {
+			if roleListed(role, roleUsers, guids, names) {
+				diags = append(diags, deleteRole(meta.(*managers.Session), role.GUID)...)
+				if diags.HasError() {
+					return diags
+				}
+			}
+		}
+
+		return diags
+	}
+}
+
+// reconcileRoles removes the roles given to users out of the desired list
+// then creates the missing ones
+func reconcileRoles(scope roleScope, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	roles, roleUsers, diags := listRoles(scope, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	guids := d.Get("users").(*schema.Set)
+	names := roleUsernames(d)
+	existing := make(map[string]bool)
+	for _, role := range roles {
+		if !roleListed(role, roleUsers, guids, names) {
+			diags = append(diags, deleteRole(session, role.GUID)...)
+			if diags.HasError() {
+				return diags
+			}
+			continue
+		}
+		user := roleUsers[role.UserGUID]
+		existing[role.UserGUID] = true
+		existing[usernameKey(user.Username, user.Origin)] = true
+	}
+
+	missing := make([]resources.Role, 0)
+	for _, guid := range setToStrings(guids) {
+		if !existing[guid] {
+			missing = append(missing, resources.Role{UserGUID: guid})
+		}
+	}
+	// the cloud controller resolves the users given by name within their origin
+	for key, name := range names {
+		if !existing[key] {
+			missing = append(missing, resources.Role{
+				Username: name["username"].(string),
+				Origin:   name["origin"].(string),
+			})
+		}
+	}
+
+	for _, role := range missing {
+		role.Type = constant.RoleType(d.Get("type").(string))
+		_, warns, err := session.ClientV3.CreateRole(scope.setOn(role, d.Get(scope.attr).(string)))
+		diags = append(diags, diagFromClient("create-"+scope.name+"-role", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// roleUsernames returns the users listed by name, by their username key
+func roleUsernames(d *schema.ResourceData) map[string]map[string]interface{} {
+	names := make(map[string]map[string]interface{})
+	for _, name := range d.Get("usernames").(*schema.Set).List() {
+		name := name.(map[string]interface{})
+		names[usernameKey(name["username"].(string), name["origin"].(string))] = name
+	}
+	return names
+}
+
+// usernameKey identifies a user by name, usernames are case insensitive
+// within an origin
+func usernameKey(username, origin string) string {
+	return origin + "/" + strings.ToLower(username)
+}
+
+// roleListed tells whether the user of the role is listed by guid or by name
+func roleListed(role resources.Role, roleUsers map[string]resources.User, guids *schema.Set, names map[string]map[string]interface{}) bool {
+	if guids.Contains(role.UserGUID) {
+		return true
+	}
+	user := roleUsers[role.UserGUID]
+	_, ok := names[usernameKey(user.Username, user.Origin)]
+	return ok
+}
+
+// listRoles returns the roles of the type with their users by guid
+func listRoles(scope roleScope, d *schema.ResourceData, meta interface{}) ([]resources.Role, map[string]resources.User, diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	roles, included, warns, err := session.ClientV3.GetRoles(
+		ccv3.Query{Key: scope.queryFilter, Values: []string{d.Get(scope.attr).(string)}},
+		ccv3.Query{Key: ccv3.RoleTypesFilter, Values: []string{d.Get("type").(string)}},
+		ccv3.Query{Key: ccv3.Include, Values: []string{"user"}},
+	)
+	users := make(map[string]resources.User, len(included.Users))
+	for _, user := range included.Users {
+		users[user.GUID] = user
+	}
+	return roles, users, diagFromClient("list-"+scope.name+"-roles", warns, err)
+}
+
+func deleteRole(session *managers.Session, guid string) diag.Diagnostics {
+	jobURL, warns, err := session.ClientV3.DeleteRole(guid)
+	if IsErrNotFound(err) {
+		return nil
+	}
+	diags := diagFromClient("delete-role", warns, err)
+	if diags.HasError() {
+		return diags
+	}
+
+	warns, err = session.ClientV3.PollJob(jobURL)
+	return append(diags, diagFromClient("poll-delete-role-job", warns, err)...)
+}
@@ -2,12 +2,16 @@ package cloudfoundry
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
 	"code.cloudfoundry.org/cli/resources"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
 )
 
@@ -17,11 +21,12 @@ func resourceRoute() *schema.Resource {
 
 		CreateContext: resourceRouteCreate,
 		ReadContext:   resourceRouteRead,
+		UpdateContext: resourceRouteUpdate,
 		DeleteContext: resourceRouteDelete,
 
-		// Importer: &schema.ResourceImporter{
-		// 	State: ImportRead(resourceRouteRead),
-		// },
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRouteImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -32,9 +37,17 @@ func resourceRoute() *schema.Resource {
 			},
 
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Description: "The GUID of the space owning the route, changing it transfers the route ownership",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+
+			"shared_space_ids": {
+				Description: "The GUIDs of the spaces the route is shared with, they can map the route to their own apps",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
 			},
 
 			"host": {
@@ -49,67 +62,223 @@ func resourceRoute() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"port": {
+				Description:   "Port to listen on, only valid for routes on a TCP domain",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.IsPortNumber,
+				ConflictsWith: []string{"random_port", "host", "path"},
+			},
+
+			"random_port": {
+				Description:   "Let cloudfoundry pick a free port from the router group, only valid for routes on a TCP domain",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"port", "host", "path"},
+				// imported tcp routes are marked with a random port, the configuration can keep the same port instead
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					oldPort, newPort := d.GetChange("port")
+					return old == "true" && new == "false" && oldPort.(int) != 0 && oldPort == newPort
+				},
+			},
+
+			"options": {
+				Description: "Per-route options applied by the gorouter",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"loadbalancing": {
+							Description:  "Load balancing algorithm used for the route destinations, one of: round-robin or least-connection",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"round-robin", "least-connection"}, false),
+						},
+					},
+				},
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"endpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
 		},
 	}
 }
 
+// routeOptions is the part of the v3 route not yet known by the ccv3 client
+type routeOptions struct {
+	Options map[string]*string `json:"options"`
+}
+
 func resourceRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	session := meta.(*managers.Session)
+	domainGUID := d.Get("domain_id").(string)
+	port := d.Get("port").(int)
+	randomPort := d.Get("random_port").(bool)
 
+	domain, warns, err := session.ClientV3.GetDomain(domainGUID)
+	diags = append(diags, diagFromClient("get-domain-for-route", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if domain.IsTCP() && port == 0 && !randomPort {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("domain %s is a TCP domain, either port or random_port must be set", domain.Name),
+			Detail:   "create-route",
+		})
+	}
+	if !domain.IsTCP() && (port != 0 || randomPort) {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("domain %s is not a TCP domain, port and random_port cannot be set", domain.Name),
+			Detail:   "create-route",
+		})
+	}
+
+	// when no port is given for a TCP domain, cloudfoundry allocates a random
+	// one from the router group reservable ports
 	route, warns, err := session.ClientV3.CreateRoute(resources.Route{
-		DomainGUID: d.Get("domain_id").(string),
+		DomainGUID: domainGUID,
 		SpaceGUID:  d.Get("space_id").(string),
 		Host:       d.Get("host").(string),
 		Path:       d.Get("path").(string),
+		Port:       port,
 	})
 	diags = append(diags, diagFromClient("create-route", warns, err)...)
 	if diags.HasError() {
 		return diags
 	}
-
 	d.SetId(route.GUID)
-	return resourceRouteRead(ctx, d, meta)
+
+	if _, ok := d.GetOk("shared_space_ids"); ok {
+		diags = append(diags, updateRouteSharedSpaces(session, d)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if _, ok := d.GetOk("options"); ok {
+		diags = append(diags, updateRouteOptions(session, d)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(routeMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceRouteRead(ctx, d, meta)...)
 }
 
 func resourceRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	session := meta.(*managers.Session)
-	domainGUID := d.Get("domain_id").(string)
-	spaceGUID := d.Get("space_id").(string)
-	host := d.Get("host").(string)
-	path := d.Get("path").(string)
 
-	domain, warns, err := session.ClientV3.GetDomain(domainGUID)
-	diags = append(diags, diagFromClient("get-domain-for-route", warns, err)...)
+	var raw json.RawMessage
+	_, warns, err := rawRequest(session, "GET", "/v3/routes/"+d.Id(), nil, &raw)
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-route", warns, err)...)
 	if diags.HasError() {
 		return diags
 	}
 
-	routes, warns, err := session.ClientV3.GetRoutes(
-		ccv3.Query{Key: ccv3.DomainGUIDFilter, Values: []string{domainGUID}},
-		ccv3.Query{Key: ccv3.SpaceGUIDFilter, Values: []string{spaceGUID}},
-		ccv3.Query{Key: ccv3.HostsFilter, Values: []string{host}},
-		ccv3.Query{Key: ccv3.PathsFilter, Values: []string{path}},
-	)
-	diags = append(diags, diagFromClient("get-routes", warns, err)...)
+	var route resources.Route
+	var opts routeOptions
+	if err := json.Unmarshal(raw, &route); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := json.Unmarshal(raw, &opts); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	domain, warns, err := session.ClientV3.GetDomain(route.DomainGUID)
+	diags = append(diags, diagFromClient("get-domain-for-route", warns, err)...)
 	if diags.HasError() {
 		return diags
 	}
-	if len(routes) != 1 {
-		d.SetId("")
+
+	sharedSpaces, warns, err := getRouteSharedSpaces(session, d.Id())
+	diags = append(diags, diagFromClient("get-route-shared-spaces", warns, err)...)
+	if diags.HasError() {
 		return diags
 	}
-	route := routes[0]
 
-	endpoint := fmt.Sprintf("%s.%s", route.Host, domain.Name)
-	if route.Path != "" {
-		endpoint += "/" + route.Path
+	_ = d.Set("domain_id", route.DomainGUID)
+	_ = d.Set("space_id", route.SpaceGUID)
+	_ = d.Set("shared_space_ids", sharedSpaces)
+	_ = d.Set("host", route.Host)
+	_ = d.Set("path", route.Path)
+	_ = d.Set("port", route.Port)
+	_ = d.Set("protocol", route.Protocol)
+	_ = d.Set("endpoint", routeEndpoint(route, domain))
+
+	options := []interface{}{}
+	if lb := opts.Options["loadbalancing"]; lb != nil {
+		options = append(options, map[string]interface{}{
+			"loadbalancing": *lb,
+		})
 	}
-	_ = d.Set("endpoint", endpoint)
-	return diags
+	_ = d.Set("options", options)
+
+	return append(diags, metadataRead(routeMetadata, d, meta, false)...)
+}
+
+func resourceRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if d.HasChange("space_id") {
+		body := map[string]interface{}{
+			"data": map[string]string{"guid": d.Get("space_id").(string)},
+		}
+		_, warns, err := rawRequest(session, "PATCH", "/v3/routes/"+d.Id()+"/relationships/space", body, nil)
+		diags = append(diags, diagFromClient("transfer-route-owner", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// the previous owner keeps access as a shared space after a transfer,
+	// so shares are always reconciled after an ownership change
+	if d.HasChanges("space_id", "shared_space_ids") {
+		diags = append(diags, updateRouteSharedSpaces(session, d)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("options") {
+		diags = append(diags, updateRouteOptions(session, d)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChanges(labelsKey, annotationsKey) {
+		diags = append(diags, metadataUpdate(routeMetadata, d, meta)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceRouteRead(ctx, d, meta)...)
 }
 
 func resourceRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
@@ -123,3 +292,170 @@ func resourceRouteDelete(ctx context.Context, d *schema.ResourceData, meta inter
 
 	return diags
 }
+
+// resourceRouteImport accepts either a route GUID or a route url in the form
+// host.domain/path (http routes) or domain:port (tcp routes)
+func resourceRouteImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	session := meta.(*managers.Session)
+
+	if strings.ContainsAny(d.Id(), ".:/") {
+		guid, err := findRouteGUIDByURL(session, d.Id())
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(guid)
+	}
+
+	imported, err := ImportReadContext(resourceRouteRead)(ctx, d, meta)
+	if err != nil {
+		return imported, err
+	}
+	// the port of a tcp route can't tell if it was picked by cloudfoundry
+	if d.Get("port").(int) != 0 {
+		_ = d.Set("random_port", true)
+	}
+	return imported, nil
+}
+
+// updateRouteOptions patches the route options, options removed from the
+// configuration are sent as null to be unset
+func updateRouteOptions(session *managers.Session, d *schema.ResourceData) diag.Diagnostics {
+	opts := routeOptions{Options: map[string]*string{"loadbalancing": nil}}
+	if v, ok := d.GetOk("options.0.loadbalancing"); ok && v.(string) != "" {
+		lb := v.(string)
+		opts.Options["loadbalancing"] = &lb
+	}
+
+	_, warns, err := rawRequest(session, "PATCH", "/v3/routes/"+d.Id(), opts, nil)
+	return diagFromClient("update-route-options", warns, err)
+}
+
+func getRouteSharedSpaces(session *managers.Session, routeGUID string) ([]string, ccv3.Warnings, error) {
+	var shared struct {
+		Data []struct {
+			GUID string `json:"guid"`
+		} `json:"data"`
+	}
+	_, warns, err := rawRequest(session, "GET", "/v3/routes/"+routeGUID+"/relationships/shared_spaces", nil, &shared)
+	if err != nil {
+		return nil, warns, err
+	}
+	spaceGUIDs := []string{}
+	for _, space := range shared.Data {
+		spaceGUIDs = append(spaceGUIDs, space.GUID)
+	}
+	return spaceGUIDs, warns, nil
+}
+
+// updateRouteSharedSpaces shares and unshares the route so that the spaces it
+// is shared with match shared_space_ids
+func updateRouteSharedSpaces(session *managers.Session, d *schema.ResourceData) (diags diag.Diagnostics) {
+	current, warns, err := getRouteSharedSpaces(session, d.Id())
+	diags = append(diags, diagFromClient("get-route-shared-spaces", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	currentSet := schema.NewSet(schema.HashString, []interface{}{})
+	for _, guid := range current {
+		currentSet.Add(guid)
+	}
+	desiredSet := d.Get("shared_space_ids").(*schema.Set)
+
+	toShare := []map[string]string{}
+	for _, guid := range desiredSet.Difference(currentSet).List() {
+		toShare = append(toShare, map[string]string{"guid": guid.(string)})
+	}
+	if len(toShare) > 0 {
+		body := map[string]interface{}{"data": toShare}
+		_, warns, err := rawRequest(session, "POST", "/v3/routes/"+d.Id()+"/relationships/shared_spaces", body, nil)
+		diags = append(diags, diagFromClient("share-route", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	for _, guid := range currentSet.Difference(desiredSet).List() {
+		_, warns, err := rawRequest(session, "DELETE", "/v3/routes/"+d.Id()+"/relationships/shared_spaces/"+guid.(string), nil, nil)
+		diags = append(diags, diagFromClient("unshare-route", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func findRouteGUIDByURL(session *managers.Session, routeURL string) (string, error) {
+	routeURL = strings.TrimPrefix(strings.TrimPrefix(routeURL, "https://"), "http://")
+
+	fqdn := routeURL
+	path := ""
+	if i := strings.Index(routeURL, "/"); i >= 0 {
+		fqdn = routeURL[:i]
+		path = routeURL[i:]
+	}
+
+	port := ""
+	if i := strings.LastIndex(fqdn, ":"); i >= 0 {
+		port = fqdn[i+1:]
+		fqdn = fqdn[:i]
+		if _, err := strconv.Atoi(port); err != nil {
+			return "", fmt.Errorf("invalid port '%s' in route url '%s'", port, routeURL)
+		}
+	}
+
+	// host names cannot contain dots, the route is either on the domain
+	// itself or on the domain following the first label
+	candidates := map[string]string{fqdn: ""}
+	if i := strings.Index(fqdn, "."); i >= 0 && port == "" {
+		candidates[fqdn[i+1:]] = fqdn[:i]
+	}
+	names := []string{}
+	for name := range candidates {
+		names = append(names, name)
+	}
+
+	domains, _, err := session.ClientV3.GetDomains(
+		ccv3.Query{Key: ccv3.NameFilter, Values: names},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for _, domain := range domains {
+		query := []ccv3.Query{
+			{Key: ccv3.DomainGUIDFilter, Values: []string{domain.GUID}},
+			{Key: ccv3.HostsFilter, Values: []string{candidates[domain.Name]}},
+			{Key: ccv3.PathsFilter, Values: []string{path}},
+		}
+		if port != "" {
+			query = append(query, ccv3.Query{Key: ccv3.PortsFilter, Values: []string{port}})
+		}
+		routes, _, err := session.ClientV3.GetRoutes(query...)
+		if err != nil {
+			return "", err
+		}
+		if len(routes) == 1 {
+			return routes[0].GUID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no route found matching '%s'", routeURL)
+}
+
+// routeEndpoint returns host.domain/path for http routes and domain:port for
+// tcp routes
+func routeEndpoint(route resources.Route, domain resources.Domain) string {
+	if domain.IsTCP() {
+		return fmt.Sprintf("%s:%d", domain.Name, route.Port)
+	}
+	endpoint := domain.Name
+	if route.Host != "" {
+		endpoint = fmt.Sprintf("%s.%s", route.Host, domain.Name)
+	}
+	if route.Path != "" {
+		endpoint += "/" + strings.TrimPrefix(route.Path, "/")
+	}
+	return endpoint
+}
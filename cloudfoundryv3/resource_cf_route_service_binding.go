@@ -0,0 +1,175 @@
+package cloudfoundry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/resources"
+	"code.cloudfoundry.org/cli/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceRouteServiceBinding() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "binding of a route to a managed or user-provided route service, requests of the route go through the service",
+
+		CreateContext: resourceRouteServiceBindingCreate,
+		ReadContext:   resourceRouteServiceBindingRead,
+		UpdateContext: resourceRouteServiceBindingUpdate,
+		DeleteContext: resourceRouteServiceBindingDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceRouteServiceBindingRead),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"service_instance": {
+				Description: "GUID of the route service instance",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"route": {
+				Description: "GUID of the route",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"params": {
+				Description:      "JSON parameters sent to the broker, only for managed route services",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+
+			"route_service_url": {
+				Description: "URL requests of the route are forwarded to",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func resourceRouteServiceBindingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	serviceInstance := d.Get("service_instance").(string)
+	route := d.Get("route").(string)
+
+	binding := resources.RouteBinding{
+		ServiceInstanceGUID: serviceInstance,
+		RouteGUID:           route,
+	}
+	if jsonParameters := d.Get("params").(string); jsonParameters != "" {
+		params := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(jsonParameters), &params); err != nil {
+			return diag.FromErr(err)
+		}
+		binding.Parameters = types.NewOptionalObject(params)
+	}
+
+	jobURL, warns, err := session.ClientV3.CreateRouteBinding(binding)
+	diags = append(diags, diagFromClient("create-route-service-binding", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// bindings of managed route services exist as soon as the job is queued,
+	// the binding is tainted when the broker fails
+	bindings, _, warns, err := session.ClientV3.GetRouteBindings(
+		ccv3.Query{Key: ccv3.ServiceInstanceGUIDFilter, Values: []string{serviceInstance}},
+		ccv3.Query{Key: ccv3.RouteGUIDFilter, Values: []string{route}},
+	)
+	diags = append(diags, diagFromClient("get-route-service-bindings", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(bindings) == 0 {
+		return append(diags, diag.FromErr(fmt.Errorf("binding of route %s to service instance %s not found after creation", route, serviceInstance))...)
+	}
+	d.SetId(bindings[0].GUID)
+
+	// bindings of user-provided route services are synchronous
+	if jobURL != "" {
+		diags = append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutCreate))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(routeServiceBindingMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceRouteServiceBindingRead(ctx, d, meta)...)
+}
+
+func resourceRouteServiceBindingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	var binding resources.RouteBinding
+	_, warns, err := rawRequest(session, "GET", "/v3/service_route_bindings/"+d.Id(), nil, &binding)
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-route-service-binding", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	_ = d.Set("service_instance", binding.ServiceInstanceGUID)
+	_ = d.Set("route", binding.RouteGUID)
+	_ = d.Set("route_service_url", binding.RouteServiceURL)
+
+	return append(diags, metadataRead(routeServiceBindingMetadata, d, meta, false)...)
+}
+
+func resourceRouteServiceBindingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	diags = append(diags, metadataUpdate(routeServiceBindingMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceRouteServiceBindingRead(ctx, d, meta)...)
+}
+
+func resourceRouteServiceBindingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	jobURL, warns, err := rawRequest(session, "DELETE", "/v3/service_route_bindings/"+d.Id(), nil, nil)
+	if IsErrNotFound(err) {
+		return diags
+	}
+	diags = append(diags, diagFromClient("delete-route-service-binding", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if jobURL != "" {
+		diags = append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutDelete))...)
+	}
+	return diags
+}
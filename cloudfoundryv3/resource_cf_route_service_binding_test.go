@@ -0,0 +1,127 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/resources"
+	"code.cloudfoundry.org/cli/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResRouteServiceBinding_userProvided(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_route" "proxied" {
+			domain_id = %q
+			space_id = %q
+			host = "tf-acc-route-service"
+		}
+		resource "cloudfoundry_route_service_binding" "proxy" {
+			service_instance = %q
+			route = cloudfoundry_route.proxied.id
+			labels = {
+				purpose = %q
+			}
+		}
+	`
+
+	space := testAccEnv.Space
+	domain := testAccEnv.Domain
+	proxyURL := "https://tf-acc-proxy.example.com"
+
+	// the v3 provider has no user-provided service instance resource
+	proxy := resources.ServiceInstance{
+		Type:            resources.UserProvidedServiceInstance,
+		Name:            "tf-acc-route-service",
+		SpaceGUID:       space.GUID,
+		RouteServiceURL: types.NewOptionalString(proxyURL),
+	}
+	if _, _, err := testAccEnv.Session.ClientV3.CreateServiceInstance(proxy); err != nil {
+		t.Fatal(err)
+	}
+	proxy, _, _, err := testAccEnv.Session.ClientV3.GetServiceInstanceByNameAndSpace(proxy.Name, space.GUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testAccEnv.Session.ClientV3.DeleteServiceInstance(proxy.GUID)
+
+	ref := "cloudfoundry_route_service_binding.proxy"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckRouteServiceBindingDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, domain.GUID, space.GUID, proxy.GUID, "waf"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRouteServiceBindingExists(ref),
+						resource.TestCheckResourceAttr(ref, "route_service_url", proxyURL),
+						resource.TestCheckResourceAttr(ref, "labels.purpose", "waf"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, domain.GUID, space.GUID, proxy.GUID, "auth"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRouteServiceBindingExists(ref),
+						resource.TestCheckResourceAttr(ref, "labels.purpose", "auth"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckRouteServiceBindingExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("route service binding '%s' not found in terraform state", resource)
+		}
+
+		bindings, _, _, err := testAccEnv.Session.ClientV3.GetRouteBindings(
+			ccv3.Query{Key: ccv3.ServiceInstanceGUIDFilter, Values: []string{rs.Primary.Attributes["service_instance"]}},
+			ccv3.Query{Key: ccv3.RouteGUIDFilter, Values: []string{rs.Primary.Attributes["route"]}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(bindings) != 1 || bindings[0].GUID != rs.Primary.ID {
+			return fmt.Errorf("route service binding %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRouteServiceBindingDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		bindings, _, _, err := testAccEnv.Session.ClientV3.GetRouteBindings(
+			ccv3.Query{Key: ccv3.ServiceInstanceGUIDFilter, Values: []string{rs.Primary.Attributes["service_instance"]}},
+			ccv3.Query{Key: ccv3.RouteGUIDFilter, Values: []string{rs.Primary.Attributes["route"]}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(bindings) > 0 {
+			return fmt.Errorf("route service binding %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
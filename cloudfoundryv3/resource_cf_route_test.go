@@ -1 +1,162 @@
 package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResRoute_normal(t *testing.T) {
+
+	space := testAccEnv.Space
+	domain := testAccEnv.Domain
+
+	src := `
+		resource "cloudfoundry_space" "other" {
+			name = "tf-acc-route-other"
+			org = %q
+		}
+		resource "cloudfoundry_route" "basic" {
+			domain_id = %q
+			space_id = %s
+			host = "route-basic"
+			path = "/api"
+			shared_space_ids = [ %s ]
+			%s
+			labels = {
+				env = %q
+			}
+		}
+	`
+	org := testAccEnv.Organization.GUID
+	owner := fmt.Sprintf("%q", space.GUID)
+	options := `options { loadbalancing = "least-connection" }`
+
+	ref := "cloudfoundry_route.basic"
+	var routeID string
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckRouteDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, org, domain.GUID, owner, "", "", "dev"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRouteExists(ref),
+						testAccCheckRouteID(ref, &routeID),
+						resource.TestCheckResourceAttr(ref, "endpoint", "route-basic."+domain.Name+"/api"),
+						resource.TestCheckResourceAttr(ref, "labels.env", "dev"),
+						resource.TestCheckResourceAttr(ref, "options.#", "0"),
+						resource.TestCheckResourceAttr(ref, "space_id", space.GUID),
+						resource.TestCheckResourceAttr(ref, "shared_space_ids.#", "0"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, org, domain.GUID, owner, "", options, "prod"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRouteExists(ref),
+						resource.TestCheckResourceAttr(ref, "labels.env", "prod"),
+						resource.TestCheckResourceAttr(ref, "options.0.loadbalancing", "least-connection"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, org, domain.GUID, owner, "cloudfoundry_space.other.id", options, "prod"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRouteID(ref, &routeID),
+						resource.TestCheckResourceAttr(ref, "shared_space_ids.#", "1"),
+						resource.TestCheckTypeSetElemAttrPair(ref, "shared_space_ids.*", "cloudfoundry_space.other", "id"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, org, domain.GUID, owner, "", options, "prod"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRouteID(ref, &routeID),
+						resource.TestCheckResourceAttr(ref, "shared_space_ids.#", "0"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, org, domain.GUID, "cloudfoundry_space.other.id", "", options, "prod"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckRouteID(ref, &routeID),
+						resource.TestCheckResourceAttrPair(ref, "space_id", "cloudfoundry_space.other", "id"),
+						resource.TestCheckResourceAttr(ref, "shared_space_ids.#", "0"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateId:     "route-basic." + domain.Name + "/api",
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+// testAccCheckRouteID records the guid of the route on its first call, the
+// next calls fail if the route has been recreated
+func testAccCheckRouteID(resource string, id *string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("route '%s' not found in terraform state", resource)
+		}
+		if *id == "" {
+			*id = rs.Primary.ID
+		}
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("expected route %s to be updated in place, got route %s", *id, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRouteExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("route '%s' not found in terraform state", resource)
+		}
+
+		routes, _, err := testAccEnv.Session.ClientV3.GetRoutes(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(routes) != 1 {
+			return fmt.Errorf("route %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRouteDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		routes, _, err := testAccEnv.Session.ClientV3.GetRoutes(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(routes) > 0 {
+			return fmt.Errorf("route %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,380 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+const (
+	protocolICMP = "icmp"
+	protocolTCP  = "tcp"
+	protocolUDP  = "udp"
+	protocolALL  = "all"
+)
+
+func resourceSecurityGroup() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "application security group with its global enablement and the spaces it is bound to",
+
+		CreateContext: resourceSecurityGroupCreate,
+		ReadContext:   resourceSecurityGroupRead,
+		UpdateContext: resourceSecurityGroupUpdate,
+		DeleteContext: resourceSecurityGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceSecurityGroupRead),
+		},
+
+		CustomizeDiff: securityGroupRulesDiff,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"rule": {
+				Description: "Egress traffic allowed, rules are evaluated as a whole so their order does not matter",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						"protocol": {
+							Description:  "Protocol allowed, one of: tcp, udp, icmp or all",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{protocolTCP, protocolUDP, protocolICMP, protocolALL}, false),
+						},
+
+						"destination": {
+							Description:  "IP address (10.0.0.1), CIDR (10.0.0.0/24), range (10.0.0.1-10.0.0.9) or a comma separated list of them",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateASGDestination,
+						},
+
+						"ports": {
+							Description:  "Port (443), range (8080-8090) or comma separated list of them, required by tcp and udp",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateASGPorts,
+						},
+
+						"type": {
+							Description:  "ICMP type, -1 for all types, only for icmp",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(-1, 255),
+						},
+
+						"code": {
+							Description:  "ICMP code, -1 for all codes, only for icmp",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(-1, 255),
+						},
+
+						"log": {
+							Description: "Log the connections, only for tcp",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"globally_enabled_running": {
+				Description: "Apply the group to the running apps of all spaces",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"globally_enabled_staging": {
+				Description: "Apply the group to the staging apps of all spaces",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"running_spaces": {
+				Description: "GUIDs of the spaces whose running apps get the group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"staging_spaces": {
+				Description: "GUIDs of the spaces whose staging apps get the group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	running := d.Get("globally_enabled_running").(bool)
+	staging := d.Get("globally_enabled_staging").(bool)
+	sg, warns, err := session.ClientV3.CreateSecurityGroup(resources.SecurityGroup{
+		Name:                   d.Get("name").(string),
+		Rules:                  securityGroupRulesFromResource(d),
+		RunningGloballyEnabled: &running,
+		StagingGloballyEnabled: &staging,
+		RunningSpaceGUIDs:      setToStrings(d.Get("running_spaces").(*schema.Set)),
+		StagingSpaceGUIDs:      setToStrings(d.Get("staging_spaces").(*schema.Set)),
+	})
+	diags = append(diags, diagFromClient("create-security-group", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(sg.GUID)
+
+	return append(diags, resourceSecurityGroupRead(ctx, d, meta)...)
+}
+
+func resourceSecurityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	sgs, warns, err := session.ClientV3.GetSecurityGroups(
+		ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{d.Id()}},
+	)
+	diags = append(diags, diagFromClient("get-security-group", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(sgs) == 0 {
+		d.SetId("")
+		return diags
+	}
+	sg := sgs[0]
+
+	_ = d.Set("name", sg.Name)
+	_ = d.Set("rule", securityGroupRulesToResource(sg.Rules))
+	_ = d.Set("globally_enabled_running", sg.RunningGloballyEnabled != nil && *sg.RunningGloballyEnabled)
+	_ = d.Set("globally_enabled_staging", sg.StagingGloballyEnabled != nil && *sg.StagingGloballyEnabled)
+	_ = d.Set("running_spaces", sg.RunningSpaceGUIDs)
+	_ = d.Set("staging_spaces", sg.StagingSpaceGUIDs)
+
+	return diags
+}
+
+func resourceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if d.HasChanges("name", "rule", "globally_enabled_running", "globally_enabled_staging") {
+		// the cli resource omits an empty rule list, removing all the rules
+		// needs an explicit one
+		payload := map[string]interface{}{
+			"name":  d.Get("name").(string),
+			"rules": securityGroupRulesFromResource(d),
+			"globally_enabled": map[string]interface{}{
+				"running": d.Get("globally_enabled_running").(bool),
+				"staging": d.Get("globally_enabled_staging").(bool),
+			},
+		}
+		_, warns, err := rawRequest(session, "PATCH", "/v3/security_groups/"+d.Id(), payload, nil)
+		diags = append(diags, diagFromClient("update-security-group", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("running_spaces") {
+		o, n := d.GetChange("running_spaces")
+		for _, space := range setToStrings(o.(*schema.Set).Difference(n.(*schema.Set))) {
+			warns, err := session.ClientV3.UnbindSecurityGroupRunningSpace(d.Id(), space)
+			if IsErrNotFound(err) {
+				continue
+			}
+			diags = append(diags, diagFromClient("unbind-security-group-running-space", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		if added := setToStrings(n.(*schema.Set).Difference(o.(*schema.Set))); len(added) > 0 {
+			warns, err := session.ClientV3.UpdateSecurityGroupRunningSpace(d.Id(), added)
+			diags = append(diags, diagFromClient("bind-security-group-running-spaces", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	if d.HasChange("staging_spaces") {
+		o, n := d.GetChange("staging_spaces")
+		for _, space := range setToStrings(o.(*schema.Set).Difference(n.(*schema.Set))) {
+			warns, err := session.ClientV3.UnbindSecurityGroupStagingSpace(d.Id(), space)
+			if IsErrNotFound(err) {
+				continue
+			}
+			diags = append(diags, diagFromClient("unbind-security-group-staging-space", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		if added := setToStrings(n.(*schema.Set).Difference(o.(*schema.Set))); len(added) > 0 {
+			warns, err := session.ClientV3.UpdateSecurityGroupStagingSpace(d.Id(), added)
+			diags = append(diags, diagFromClient("bind-security-group-staging-spaces", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return append(diags, resourceSecurityGroupRead(ctx, d, meta)...)
+}
+
+func resourceSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	jobURL, warns, err := session.ClientV3.DeleteSecurityGroup(d.Id())
+	if IsErrNotFound(err) {
+		return diags
+	}
+	diags = append(diags, diagFromClient("delete-security-group", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	warns, err = session.ClientV3.PollJob(jobURL)
+	return append(diags, diagFromClient("poll-delete-security-group-job", warns, err)...)
+}
+
+func securityGroupRulesFromResource(d *schema.ResourceData) []resources.Rule {
+	rules := make([]resources.Rule, 0)
+	for _, r := range d.Get("rule").([]interface{}) {
+		tfRule := r.(map[string]interface{})
+		rule := resources.Rule{
+			Protocol:    tfRule["protocol"].(string),
+			Destination: tfRule["destination"].(string),
+		}
+		if ports := tfRule["ports"].(string); ports != "" {
+			rule.Ports = &ports
+		}
+		if rule.Protocol == protocolICMP {
+			icmpType, icmpCode := tfRule["type"].(int), tfRule["code"].(int)
+			rule.Type, rule.Code = &icmpType, &icmpCode
+		}
+		if rule.Protocol == protocolTCP {
+			log := tfRule["log"].(bool)
+			rule.Log = &log
+		}
+		if description := tfRule["description"].(string); description != "" {
+			rule.Description = &description
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func securityGroupRulesToResource(rules []resources.Rule) []interface{} {
+	tfRules := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		tfRule := map[string]interface{}{
+			"protocol":    rule.Protocol,
+			"destination": rule.Destination,
+			"ports":       "",
+			"type":        0,
+			"code":        0,
+			"log":         false,
+			"description": "",
+		}
+		if rule.Ports != nil {
+			tfRule["ports"] = *rule.Ports
+		}
+		if rule.Type != nil {
+			tfRule["type"] = *rule.Type
+		}
+		if rule.Code != nil {
+			tfRule["code"] = *rule.Code
+		}
+		if rule.Log != nil {
+			tfRule["log"] = *rule.Log
+		}
+		if rule.Description != nil {
+			tfRule["description"] = *rule.Description
+		}
+		tfRules = append(tfRules, tfRule)
+	}
+	return tfRules
+}
+
+// securityGroupRulesDiff checks the arguments each protocol accepts, values
+// still unknown at plan time are left to the cloud controller
+func securityGroupRulesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for i, r := range d.Get("rule").([]interface{}) {
+		if r == nil {
+			continue
+		}
+		tfRule := r.(map[string]interface{})
+		key := fmt.Sprintf("rule.%d", i)
+		if !d.NewValueKnown(key+".protocol") || !d.NewValueKnown(key+".ports") {
+			continue
+		}
+
+		protocol, ports := tfRule["protocol"].(string), tfRule["ports"].(string)
+		switch protocol {
+		case protocolTCP, protocolUDP:
+			if ports == "" {
+				return fmt.Errorf("%s: ports is required by the %s protocol", key, protocol)
+			}
+		default:
+			if ports != "" {
+				return fmt.Errorf("%s: ports is only valid for the tcp and udp protocols", key)
+			}
+		}
+		if protocol != protocolICMP && (tfRule["type"].(int) != 0 || tfRule["code"].(int) != 0) {
+			return fmt.Errorf("%s: type and code are only valid for the icmp protocol", key)
+		}
+		if protocol != protocolTCP && tfRule["log"].(bool) {
+			return fmt.Errorf("%s: log is only valid for the tcp protocol", key)
+		}
+	}
+	return nil
+}
+
+func validateASGDestination(v interface{}, k string) (warnings []string, errors []error) {
+	for _, dest := range strings.Split(v.(string), ",") {
+		if err := ipDestinationParse(strings.TrimSpace(dest)); err != nil {
+			errors = append(errors, fmt.Errorf("%q: invalid destination %q, %s", k, dest, err))
+		}
+	}
+	return warnings, errors
+}
+
+func validateASGPorts(v interface{}, k string) (warnings []string, errors []error) {
+	if v.(string) == "" {
+		return warnings, errors
+	}
+	for _, ports := range strings.Split(v.(string), ",") {
+		w, errs := validatePortRange(strings.TrimSpace(ports), k)
+		warnings = append(warnings, w...)
+		errors = append(errors, errs...)
+	}
+	return warnings, errors
+}
@@ -0,0 +1,165 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResSecurityGroup_normal(t *testing.T) {
+
+	space := testAccEnv.Space
+
+	src := `
+		resource "cloudfoundry_security_group" "sg1" {
+			name = "tf-acc-security-group"
+			rule {
+				protocol = "tcp"
+				destination = "192.168.1.0/24,10.0.0.1-10.0.0.9"
+				ports = "443,8080-8090"
+				log = true
+				description = "backends"
+			}
+			rule {
+				protocol = "icmp"
+				destination = "10.0.1.0/24"
+				type = %d
+				code = -1
+			}
+			globally_enabled_staging = %t
+			running_spaces = [ %s ]
+		}
+	`
+
+	ref := "cloudfoundry_security_group.sg1"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckSecurityGroupDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, 0, false, fmt.Sprintf("%q", space.GUID)),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckSecurityGroupExists(ref),
+						resource.TestCheckResourceAttr(ref, "rule.#", "2"),
+						resource.TestCheckResourceAttr(ref, "rule.0.ports", "443,8080-8090"),
+						resource.TestCheckResourceAttr(ref, "rule.0.log", "true"),
+						resource.TestCheckResourceAttr(ref, "rule.1.code", "-1"),
+						resource.TestCheckResourceAttr(ref, "globally_enabled_running", "false"),
+						resource.TestCheckResourceAttr(ref, "running_spaces.#", "1"),
+						resource.TestCheckResourceAttr(ref, "staging_spaces.#", "0"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, 8, true, ""),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckSecurityGroupExists(ref),
+						resource.TestCheckResourceAttr(ref, "rule.1.type", "8"),
+						resource.TestCheckResourceAttr(ref, "globally_enabled_staging", "true"),
+						resource.TestCheckResourceAttr(ref, "running_spaces.#", "0"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func TestAccResSecurityGroup_invalidRules(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_security_group" "sg2" {
+			name = "tf-acc-security-group-invalid"
+			rule {
+				protocol = %q
+				destination = %q
+				ports = %q
+			}
+		}
+	`
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(src, "tcp", "10.0.0.0/33", "443"),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`invalid destination "10.0.0.0/33"`),
+				},
+				{
+					Config:      fmt.Sprintf(src, "tcp", "10.0.0.9-10.0.0.1", "443"),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`range start must not exceed range end`),
+				},
+				{
+					Config:      fmt.Sprintf(src, "udp", "10.0.0.1", ""),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`ports is required by the udp protocol`),
+				},
+				{
+					Config:      fmt.Sprintf(src, "all", "10.0.0.1", "53"),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(`ports is only valid for the tcp and udp protocols`),
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckSecurityGroupExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("security group '%s' not found in terraform state", resource)
+		}
+
+		sgs, _, err := testAccEnv.Session.ClientV3.GetSecurityGroups(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(sgs) != 1 {
+			return fmt.Errorf("security group %s not found", rs.Primary.ID)
+		}
+		if fmt.Sprint(len(sgs[0].RunningSpaceGUIDs)) != rs.Primary.Attributes["running_spaces.#"] {
+			return fmt.Errorf("expected %s running spaces, got %d", rs.Primary.Attributes["running_spaces.#"], len(sgs[0].RunningSpaceGUIDs))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSecurityGroupDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		sgs, _, err := testAccEnv.Session.ClientV3.GetSecurityGroups(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(sgs) > 0 {
+			return fmt.Errorf("security group %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
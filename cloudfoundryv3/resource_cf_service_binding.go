@@ -153,5 +153,8 @@ func IsErrNotFound(err error) bool {
 	if uaaErr, ok := err.(uaa.RawHTTPStatusError); ok && uaaErr.StatusCode == 404 {
 		return true
 	}
+	if uaaErr, ok := err.(UAAStatusError); ok && uaaErr.StatusCode == 404 {
+		return true
+	}
 	return false
 }
@@ -0,0 +1,367 @@
+package cloudfoundry
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+const (
+	catalogEndpoint = "/v2/catalog"
+)
+
+func resourceServiceBroker() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "global or space-scoped service broker, its catalog is synchronized again when it changes",
+
+		CreateContext: resourceServiceBrokerCreate,
+		ReadContext:   resourceServiceBrokerRead,
+		UpdateContext: resourceServiceBrokerUpdate,
+		DeleteContext: resourceServiceBrokerDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceServiceBrokerRead),
+		},
+
+		CustomizeDiff: serviceBrokerCatalogDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"url": {
+				Description:  "URL of the broker, the cloud controller must be able to reach it",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+
+			"username": {
+				Description: "Basic authentication user of the broker, never read back from the cloud controller",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+
+			"password": {
+				Description: "Basic authentication password of the broker, never read back from the cloud controller",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+
+			"space": {
+				Description: "GUID of the space of a space-scoped broker, its plans are only visible in the space",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"catalog_hash": {
+				Description: "SHA1 of the catalog of the broker as fetched by the provider, the catalog is synchronized again when it changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"fail_when_catalog_not_accessible": {
+				Description: "Fail the plan when the provider cannot fetch the catalog, otherwise changes of the catalog are not detected",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"services": {
+				Description: "GUIDs of the registered service offerings by name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"service_plans": {
+				Description: "GUIDs of the registered service plans by offering/plan name",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+// serviceBrokerCatalogDiff plans a catalog synchronization when the catalog
+// served by the broker differs from the last synchronized one
+func serviceBrokerCatalogDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("url") || !d.NewValueKnown("username") || !d.NewValueKnown("password") {
+		return d.SetNewComputed("catalog_hash")
+	}
+
+	session := meta.(*managers.Session)
+	signature, err := serviceBrokerCatalogSignature(
+		session.HttpClient,
+		d.Get("url").(string),
+		d.Get("username").(string),
+		d.Get("password").(string),
+	)
+	if err != nil {
+		if d.Get("fail_when_catalog_not_accessible").(bool) {
+			return fmt.Errorf("error when getting catalog signature: %s", err)
+		}
+		log.Printf("[WARN] skipping catalog change detection of broker %s: %s", d.Get("name").(string), err)
+		return nil
+	}
+
+	if d.Get("catalog_hash").(string) == signature {
+		return nil
+	}
+	if err := d.SetNew("catalog_hash", signature); err != nil {
+		return err
+	}
+	if d.Id() != "" {
+		if err := d.SetNewComputed("services"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("service_plans")
+	}
+	return nil
+}
+
+func resourceServiceBrokerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	name := d.Get("name").(string)
+
+	jobURL, warns, err := session.ClientV3.CreateServiceBroker(resources.ServiceBroker{
+		Name:            name,
+		URL:             d.Get("url").(string),
+		CredentialsType: resources.ServiceBrokerBasicCredentials,
+		Username:        d.Get("username").(string),
+		Password:        d.Get("password").(string),
+		SpaceGUID:       d.Get("space").(string),
+	})
+	diags = append(diags, diagFromClient("create-service-broker", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the broker exists as soon as the job is queued, it is tainted when the
+	// synchronization of its catalog fails
+	brokers, warns, err := session.ClientV3.GetServiceBrokers(
+		ccv3.Query{Key: ccv3.NameFilter, Values: []string{name}},
+	)
+	diags = append(diags, diagFromClient("get-service-broker", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(brokers) == 0 {
+		return append(diags, diag.FromErr(fmt.Errorf("service broker %s not found after creation", name))...)
+	}
+	d.SetId(brokers[0].GUID)
+
+	diags = append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutCreate))...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags = append(diags, metadataUpdate(serviceBrokerMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceServiceBrokerRead(ctx, d, meta)...)
+}
+
+func resourceServiceBrokerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	brokers, warns, err := session.ClientV3.GetServiceBrokers(
+		ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{d.Id()}},
+	)
+	diags = append(diags, diagFromClient("get-service-broker", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(brokers) == 0 {
+		d.SetId("")
+		return diags
+	}
+	broker := brokers[0]
+
+	_ = d.Set("name", broker.Name)
+	_ = d.Set("url", broker.URL)
+	_ = d.Set("space", broker.SpaceGUID)
+
+	diags = append(diags, readServiceBrokerCatalog(session, d)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, metadataRead(serviceBrokerMetadata, d, meta, false)...)
+}
+
+func resourceServiceBrokerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	var (
+		broker resources.ServiceBroker
+		update bool
+	)
+	if d.HasChange("name") {
+		broker.Name = d.Get("name").(string)
+		update = true
+	}
+	// sending the url and credentials synchronizes the catalog again
+	if d.HasChanges("url", "username", "password", "catalog_hash") {
+		broker.URL = d.Get("url").(string)
+		broker.CredentialsType = resources.ServiceBrokerBasicCredentials
+		broker.Username = d.Get("username").(string)
+		broker.Password = d.Get("password").(string)
+		update = true
+	}
+
+	if update {
+		jobURL, warns, err := session.ClientV3.UpdateServiceBroker(d.Id(), broker)
+		diags = append(diags, diagFromClient("update-service-broker", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		// a rename alone is synchronous
+		if jobURL != "" {
+			diags = append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutUpdate))...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	diags = append(diags, metadataUpdate(serviceBrokerMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceServiceBrokerRead(ctx, d, meta)...)
+}
+
+func resourceServiceBrokerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	// purging an offering removes its plans and instances without calling
+	// the broker
+	if session.PurgeWhenDelete {
+		offerings, warns, err := session.ClientV3.GetServiceOfferings(
+			ccv3.Query{Key: ccv3.ServiceBrokerGUIDsFilter, Values: []string{d.Id()}},
+		)
+		diags = append(diags, diagFromClient("get-service-offerings", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		for _, offering := range offerings {
+			warns, err = session.ClientV3.PurgeServiceOffering(offering.GUID)
+			if IsErrNotFound(err) {
+				continue
+			}
+			diags = append(diags, diagFromClient("purge-service-offering", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	jobURL, warns, err := session.ClientV3.DeleteServiceBroker(d.Id())
+	if IsErrNotFound(err) {
+		return diags
+	}
+	diags = append(diags, diagFromClient("delete-service-broker", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutDelete))...)
+}
+
+// readServiceBrokerCatalog sets the offerings and plans registered by the
+// broker
+func readServiceBrokerCatalog(session *managers.Session, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	offerings, warns, err := session.ClientV3.GetServiceOfferings(
+		ccv3.Query{Key: ccv3.ServiceBrokerGUIDsFilter, Values: []string{d.Id()}},
+	)
+	diags = append(diags, diagFromClient("get-service-offerings", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	plans, warns, err := session.ClientV3.GetServicePlans(
+		ccv3.Query{Key: ccv3.ServiceBrokerGUIDsFilter, Values: []string{d.Id()}},
+	)
+	diags = append(diags, diagFromClient("get-service-plans", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	offeringNames := make(map[string]string, len(offerings))
+	tfServices := make(map[string]interface{}, len(offerings))
+	for _, offering := range offerings {
+		offeringNames[offering.GUID] = offering.Name
+		tfServices[offering.Name] = offering.GUID
+	}
+	tfPlans := make(map[string]interface{}, len(plans))
+	for _, plan := range plans {
+		tfPlans[offeringNames[plan.ServiceOfferingGUID]+"/"+plan.Name] = plan.GUID
+	}
+
+	_ = d.Set("services", tfServices)
+	_ = d.Set("service_plans", tfPlans)
+	return diags
+}
+
+func serviceBrokerCatalogSignature(client *http.Client, brokerURL, username, password string) (string, error) {
+	req, err := http.NewRequest("GET", strings.TrimSuffix(brokerURL, "/")+catalogEndpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("X-Broker-API-Version", "2.11")
+	req.SetBasicAuth(username, password)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status code: %s, body: %s", resp.Status, string(body))
+	}
+
+	h := sha1.New()
+	if _, err := h.Write(body); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(h.Sum(nil)), nil
+}
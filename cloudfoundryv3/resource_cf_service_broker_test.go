@@ -0,0 +1,155 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccPreCheckServiceBroker(t *testing.T) {
+	testAccPreCheck(t)()
+	if os.Getenv("TEST_SERVICE_BROKER_URL") == "" {
+		t.Skip("TEST_SERVICE_BROKER_URL must be set for service broker acceptance tests")
+	}
+}
+
+func TestAccResServiceBroker_spaceScoped(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_service_broker" "broker" {
+			name = %q
+			url = %q
+			username = %q
+			password = %q
+			space = %q
+			labels = {
+				team = "data"
+			}
+		}
+	`
+
+	url := os.Getenv("TEST_SERVICE_BROKER_URL")
+	user := os.Getenv("TEST_SERVICE_BROKER_USER")
+	password := os.Getenv("TEST_SERVICE_BROKER_PASSWORD")
+	space := testAccEnv.Space.GUID
+
+	ref := "cloudfoundry_service_broker.broker"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheckServiceBroker(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckServiceBrokerDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, "tf-acc-broker", url, user, password, space),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServiceBrokerExists(ref),
+						resource.TestCheckResourceAttr(ref, "space", space),
+						resource.TestCheckResourceAttr(ref, "labels.team", "data"),
+						resource.TestCheckResourceAttrSet(ref, "catalog_hash"),
+						resource.TestMatchResourceAttr(ref, "services.%", regexp.MustCompile(`^[1-9]\d*$`)),
+						resource.TestMatchResourceAttr(ref, "service_plans.%", regexp.MustCompile(`^[1-9]\d*$`)),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, "tf-acc-broker-renamed", url, user, password, space),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServiceBrokerExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-broker-renamed"),
+					),
+				},
+				{
+					ResourceName:            ref,
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"username", "password", "catalog_hash", "fail_when_catalog_not_accessible"},
+				},
+			},
+		},
+	)
+}
+
+func TestAccResServiceBroker_catalogNotAccessible(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_service_broker" "broker" {
+			name = "tf-acc-broker-unreachable"
+			url = "%s/not/accessible"
+			username = %q
+			password = %q
+			space = %q
+			fail_when_catalog_not_accessible = true
+		}
+	`
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheckServiceBroker(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src,
+						os.Getenv("TEST_SERVICE_BROKER_URL"),
+						os.Getenv("TEST_SERVICE_BROKER_USER"),
+						os.Getenv("TEST_SERVICE_BROKER_PASSWORD"),
+						testAccEnv.Space.GUID,
+					),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile("error when getting catalog signature"),
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckServiceBrokerExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("service broker '%s' not found in terraform state", resource)
+		}
+
+		brokers, _, err := testAccEnv.Session.ClientV3.GetServiceBrokers(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(brokers) == 0 {
+			return fmt.Errorf("service broker %s not found", rs.Primary.ID)
+		}
+		if brokers[0].Name != rs.Primary.Attributes["name"] {
+			return fmt.Errorf("expected name %s, got %s", rs.Primary.Attributes["name"], brokers[0].Name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckServiceBrokerDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		brokers, _, err := testAccEnv.Session.ClientV3.GetServiceBrokers(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(brokers) > 0 {
+			return fmt.Errorf("service broker %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
@@ -3,9 +3,11 @@ package cloudfoundry
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
@@ -28,6 +30,12 @@ func resourceServiceInstance() *schema.Resource {
 		UpdateContext: resourceServiceInstanceUpdate,
 		DeleteContext: resourceServiceInstanceDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceServiceInstanceRead),
+		},
+
+		CustomizeDiff: serviceInstanceUpgradeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(15 * time.Minute),
 			Update: schema.DefaultTimeout(15 * time.Minute),
@@ -39,7 +47,6 @@ func resourceServiceInstance() *schema.Resource {
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"service_plan_id": {
@@ -54,10 +61,12 @@ func resourceServiceInstance() *schema.Resource {
 			},
 
 			"params": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Default:      "{}",
-				ValidateFunc: validation.StringIsJSON,
+				Description:      "JSON parameters sent to the broker, compared as JSON. Drift is only detected when the broker allows fetching them",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
 			},
 
 			"tags": {
@@ -65,6 +74,60 @@ func resourceServiceInstance() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"shared_space_ids": {
+				Description: "GUIDs of the spaces the instance is shared with, shares made outside of terraform are removed",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"unshare_on_delete": {
+				Description: "Unshare the instance from all spaces before deleting it, otherwise deleting a shared instance fails",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"maintenance_info": {
+				Description: "Version of the instance as published by the broker",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"upgrade_available": {
+				Description: "Whether the plan of the instance has a newer maintenance_info version",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"auto_upgrade": {
+				Description:   "Upgrade the instance whenever the plan has a newer maintenance_info version",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"maintenance_info_version"},
+			},
+
+			"maintenance_info_version": {
+				Description: "Pinned maintenance_info version of the instance, the instance is upgraded when it differs",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -121,18 +184,22 @@ func resourceServiceInstanceCreate(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(si.GUID)
 
-	return nil
+	if spaces := setToStrings(d.Get("shared_space_ids").(*schema.Set)); len(spaces) > 0 {
+		_, warns, err = s.ClientV3.ShareServiceInstanceToSpaces(si.GUID, spaces)
+		diags = append(diags, diagFromClient("share-service-instance", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceServiceInstanceRead(ctx, d, meta)...)
 }
 
 func resourceServiceInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	s := meta.(*managers.Session)
 
-	name := d.Get("name").(string)
-	spaceGUID := d.Get("space_id").(string)
-
 	serviceInstances, _, warns, err := s.ClientV3.GetServiceInstances(
-		ccv3.Query{Key: ccv3.NameFilter, Values: []string{name}},
-		ccv3.Query{Key: ccv3.SpaceGUIDFilter, Values: []string{spaceGUID}},
+		ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{d.Id()}},
 	)
 	diags = append(diags, diagFromClient("get-service-instances", warns, err)...)
 	if diags.HasError() {
@@ -144,28 +211,30 @@ func resourceServiceInstanceRead(ctx context.Context, d *schema.ResourceData, me
 	}
 	si := serviceInstances[0]
 
-	// since we don't have a "get-by-guid" we should
-	// check that we are reading from the expected service
-	if si.GUID != d.Id() {
-		d.SetId("")
-		return diags
-	}
-
-	params, warns, err := s.ClientV3.GetServiceInstanceParameters(si.GUID)
-	diags = append(diags, diagFromClient("get-service-instance-params", warns, err)...)
+	// params of brokers not allowing to fetch them keep their configured
+	// value, they would always differ otherwise
+	retrievable, retrievableDiags := serviceInstanceParamsRetrievable(s, si.ServicePlanGUID)
+	diags = append(diags, retrievableDiags...)
 	if diags.HasError() {
 		return diags
 	}
-	paramsBytes, err := params.MarshalJSON()
-	diags = append(diags, diagFromClient("marshal-service-instance-params", warns, err)...)
-	if diags.HasError() {
-		return diags
+	if retrievable {
+		params, warns, err := s.ClientV3.GetServiceInstanceParameters(si.GUID)
+		diags = append(diags, diagFromClient("get-service-instance-params", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		paramsBytes, err := params.MarshalJSON()
+		diags = append(diags, diagFromClient("marshal-service-instance-params", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		_ = d.Set("params", string(paramsBytes))
 	}
 
 	_ = d.Set("name", si.Name)
 	_ = d.Set("service_plan_id", si.ServicePlanGUID)
 	_ = d.Set("space_id", si.SpaceGUID)
-	_ = d.Set("params", string(paramsBytes))
 
 	if si.Tags.IsSet {
 		tags := make([]interface{}, len(si.Tags.Value))
@@ -177,67 +246,153 @@ func resourceServiceInstanceRead(ctx context.Context, d *schema.ResourceData, me
 		_ = d.Set("tags", nil)
 	}
 
-	return nil
+	shared, warns, err := s.ClientV3.GetServiceInstanceSharedSpaces(si.GUID)
+	diags = append(diags, diagFromClient("get-service-instance-shared-spaces", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	sharedGUIDs := make([]string, 0, len(shared))
+	for _, space := range shared {
+		sharedGUIDs = append(sharedGUIDs, space.SpaceGUID)
+	}
+	_ = d.Set("shared_space_ids", sharedGUIDs)
+
+	// the maintenance_info description is not known by the cli resource
+	var maintenance serviceInstanceMaintenance
+	_, warns, err = rawRequest(s, "GET", "/v3/service_instances/"+si.GUID, nil, &maintenance)
+	diags = append(diags, diagFromClient("get-service-instance-maintenance-info", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	_ = d.Set("maintenance_info", []interface{}{map[string]interface{}{
+		"version":     maintenance.MaintenanceInfo.Version,
+		"description": maintenance.MaintenanceInfo.Description,
+	}})
+	_ = d.Set("maintenance_info_version", maintenance.MaintenanceInfo.Version)
+	_ = d.Set("upgrade_available", maintenance.UpgradeAvailable)
+
+	return diags
 }
 
 func resourceServiceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	s := meta.(*managers.Session)
 
-	var (
-		id     string
-		name   string
-		tags   types.OptionalStringSlice
-		params types.OptionalObject
-	)
-
-	id = d.Id()
-	name = d.Get("name").(string)
-	servicePlan := d.Get("service_plan_id").(string)
-	jsonParameters := d.Get("params").(string)
+	id := d.Id()
 
-	if len(jsonParameters) > 0 {
-		err := json.Unmarshal([]byte(jsonParameters), &params)
-		if err != nil {
-			return diag.FromErr(err)
+	// only the changed fields are sent, some brokers reject updates of
+	// fields they do not support changing
+	var si resources.ServiceInstance
+	if d.HasChange("name") {
+		si.Name = d.Get("name").(string)
+	}
+	if d.HasChange("service_plan_id") {
+		si.ServicePlanGUID = d.Get("service_plan_id").(string)
+	}
+	if d.HasChange("params") {
+		params := make(map[string]interface{})
+		if jsonParameters := d.Get("params").(string); len(jsonParameters) > 0 {
+			if err := json.Unmarshal([]byte(jsonParameters), &params); err != nil {
+				return diag.FromErr(err)
+			}
 		}
+		si.Parameters = types.NewOptionalObject(params)
 	}
-
-	for _, v := range d.Get("tags").([]interface{}) {
-		tags.Value = append(tags.Value, v.(string))
-		tags.IsSet = true
+	if d.HasChange("tags") {
+		tags := make([]string, 0)
+		for _, v := range d.Get("tags").([]interface{}) {
+			tags = append(tags, v.(string))
+		}
+		si.Tags = types.NewOptionalStringSlice(tags...)
 	}
 
-	updateJobURL, warns, err := s.ClientV3.UpdateServiceInstance(id, resources.ServiceInstance{
-		Name:            name,
-		ServicePlanGUID: servicePlan,
-		Parameters:      params,
-		Tags:            tags,
-	})
-	diags = append(diags, diagFromClient("update-service-instance", warns, err)...)
-	if diags.HasError() {
-		return diags
+	if d.HasChanges("name", "service_plan_id", "params", "tags") {
+		updateJobURL, warns, err := s.ClientV3.UpdateServiceInstance(id, si)
+		diags = append(diags, diagFromClient("update-service-instance", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		// renames and tag changes do not involve the broker and are synchronous
+		if updateJobURL != "" {
+			stateConf := &resource.StateChangeConf{
+				Pending:        jobPendingStates,
+				Target:         jobSuccessStates,
+				Refresh:        jobStateFunc(s, updateJobURL),
+				Timeout:        d.Timeout(schema.TimeoutUpdate),
+				PollInterval:   30 * time.Second,
+				Delay:          5 * time.Second,
+				NotFoundChecks: 3, // if we don't find the service instance in CF during an update, something is definitely wrong
+			}
+			if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:        jobPendingStates,
-		Target:         jobSuccessStates,
-		Refresh:        jobStateFunc(s, updateJobURL),
-		Timeout:        d.Timeout(schema.TimeoutUpdate),
-		PollInterval:   30 * time.Second,
-		Delay:          5 * time.Second,
-		NotFoundChecks: 3, // if we don't find the service instance in CF during an update, something is definitely wrong
+	if d.HasChange("shared_space_ids") {
+		o, n := d.GetChange("shared_space_ids")
+		for _, space := range setToStrings(o.(*schema.Set).Difference(n.(*schema.Set))) {
+			warns, err := s.ClientV3.UnshareServiceInstanceFromSpace(id, space)
+			if IsErrNotFound(err) {
+				continue
+			}
+			diags = append(diags, diagFromClient("unshare-service-instance", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		if added := setToStrings(n.(*schema.Set).Difference(o.(*schema.Set))); len(added) > 0 {
+			_, warns, err := s.ClientV3.ShareServiceInstanceToSpaces(id, added)
+			diags = append(diags, diagFromClient("share-service-instance", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
 	}
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
-		return diag.FromErr(err)
+
+	// upgrades are sent alone, brokers may reject them with other changes
+	if d.HasChange("maintenance_info_version") {
+		diags = append(diags, upgradeServiceInstance(ctx, s, d)...)
+		if diags.HasError() {
+			return diags
+		}
 	}
 
-	return nil
+	return append(diags, resourceServiceInstanceRead(ctx, d, meta)...)
 }
 
 func resourceServiceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	s := meta.(*managers.Session)
 	id := d.Id()
 
+	// the bindings of apps in the shared spaces are lost with the shares, the
+	// instance is only unshared on request
+	shared, warns, err := s.ClientV3.GetServiceInstanceSharedSpaces(id)
+	if IsErrNotFound(err) {
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-service-instance-shared-spaces", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(shared) > 0 && !d.Get("unshare_on_delete").(bool) {
+		names := make([]string, 0, len(shared))
+		for _, space := range shared {
+			names = append(names, space.OrganizationName+"/"+space.SpaceName)
+		}
+		return append(diags, diag.Errorf("service instance %s is shared with spaces %s, unshare it or set unshare_on_delete", id, strings.Join(names, ", "))...)
+	}
+	for _, space := range shared {
+		warns, err = s.ClientV3.UnshareServiceInstanceFromSpace(id, space.SpaceGUID)
+		if IsErrNotFound(err) {
+			continue
+		}
+		diags = append(diags, diagFromClient("unshare-service-instance", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	deleteJobURL, warns, err := s.ClientV3.DeleteServiceInstance(id)
 	diags = append(diags, diagFromClient("delete-service-instance", warns, err)...)
 	if diags.HasError() {
@@ -259,25 +414,90 @@ func resourceServiceInstanceDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-// func resourceServiceInstanceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-// 	s := meta.(*managers.Session)
+// serviceInstanceParamsRetrievable tells whether the broker of the plan
+// allows fetching the parameters of its instances
+func serviceInstanceParamsRetrievable(s *managers.Session, planGUID string) (bool, diag.Diagnostics) {
+	plan, warns, err := s.ClientV3.GetServicePlanByGUID(planGUID)
+	diags := diagFromClient("get-service-plan", warns, err)
+	if diags.HasError() {
+		return false, diags
+	}
 
-// 	serviceinstance, _, err := s.ClientV2.GetServiceInstance(d.Id())
+	var offering struct {
+		BrokerCatalog struct {
+			Features struct {
+				InstancesRetrievable bool `json:"instances_retrievable"`
+			} `json:"features"`
+		} `json:"broker_catalog"`
+	}
+	_, warns, err = rawRequest(s, "GET", "/v3/service_offerings/"+plan.ServiceOfferingGUID, nil, &offering)
+	diags = append(diags, diagFromClient("get-service-offering", warns, err)...)
+	return offering.BrokerCatalog.Features.InstancesRetrievable, diags
+}
+
+// serviceInstanceMaintenance is the maintenance part of
+// /v3/service_instances
+type serviceInstanceMaintenance struct {
+	MaintenanceInfo struct {
+		Version     string `json:"version"`
+		Description string `json:"description"`
+	} `json:"maintenance_info"`
+	UpgradeAvailable bool `json:"upgrade_available"`
+}
+
+// serviceInstanceUpgradeDiff plans an upgrade of the instance when the
+// version is pinned to another one, or when an upgrade is available and
+// instances are upgraded automatically
+func serviceInstanceUpgradeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	upgrade := d.HasChange("maintenance_info_version")
+	if !upgrade && d.Get("auto_upgrade").(bool) && d.Get("upgrade_available").(bool) {
+		if err := d.SetNewComputed("maintenance_info_version"); err != nil {
+			return err
+		}
+		upgrade = true
+	}
+	if !upgrade {
+		return nil
+	}
 
-// 	if err != nil {
-// 		return nil, err
-// 	}
+	if err := d.SetNewComputed("maintenance_info"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("upgrade_available")
+}
 
-// 	d.Set("name", serviceinstance.Name)
-// 	d.Set("service_plan_id", serviceinstance.ServicePlanGUID)
-// 	d.Set("space_id", serviceinstance.SpaceGUID)
-// 	d.Set("tags", serviceinstance.Tags)
+// upgradeServiceInstance updates the instance to the pinned maintenance_info
+// version, or to the one of its plan when upgraded automatically
+func upgradeServiceInstance(ctx context.Context, s *managers.Session, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	version := d.Get("maintenance_info_version").(string)
+	if version == "" {
+		plan, warns, err := s.ClientV3.GetServicePlanByGUID(d.Get("service_plan_id").(string))
+		diags = append(diags, diagFromClient("get-service-plan", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		version = plan.MaintenanceInfoVersion
+	}
 
-// 	// json_param can't be retrieved from CF, please inject manually if necessary
-// 	d.Set("json_param", "")
+	upgradeJobURL, warns, err := s.ClientV3.UpdateServiceInstance(d.Id(), resources.ServiceInstance{
+		MaintenanceInfoVersion: version,
+	})
+	diags = append(diags, diagFromClient("upgrade-service-instance", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
 
-// 	return ImportRead(resourceServiceInstanceRead)(d, meta)
-// }
+	if upgradeJobURL != "" {
+		diags = append(diags, waitJob(ctx, s, upgradeJobURL, d.Timeout(schema.TimeoutUpdate))...)
+	}
+	return diags
+}
 
 func jobStateFunc(s *managers.Session, jobURL ccv3.JobURL) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
@@ -301,6 +521,23 @@ func jobStateFunc(s *managers.Session, jobURL ccv3.JobURL) resource.StateRefresh
 	}
 }
 
+// waitJob waits for an asynchronous job of the cloud controller to complete
+func waitJob(ctx context.Context, s *managers.Session, jobURL ccv3.JobURL, timeout time.Duration) diag.Diagnostics {
+	jobState := &resource.StateChangeConf{
+		Pending:        jobPendingStates,
+		Target:         jobSuccessStates,
+		Refresh:        jobStateFunc(s, jobURL),
+		Timeout:        timeout,
+		PollInterval:   5 * time.Second,
+		Delay:          2 * time.Second,
+		NotFoundChecks: 2,
+	}
+	if _, err := jobState.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
 var jobPendingStates = []string{
 	string(constant.JobPolling),
 	string(constant.JobProcessing),
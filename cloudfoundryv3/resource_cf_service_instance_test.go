@@ -2,6 +2,7 @@ package cloudfoundry_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
@@ -45,6 +46,182 @@ func TestAccResServiceInstancesWithAsyncPlan(t *testing.T) {
 	)
 }
 
+func TestAccResServiceInstance_rename(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_service_instance" "rename" {
+			name = %q
+			space_id = %q
+			service_plan_id = %q
+			params = %q
+			tags = [ "db" ]
+		}
+	`
+
+	space := testAccEnv.Space
+	servicePlan := testAccEnv.ServicePlan
+
+	var id string
+	ref := "cloudfoundry_service_instance.rename"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckServiceInstanceDestroyed([]string{"tf-acc-rename", "tf-acc-renamed"}, ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, "tf-acc-rename", space.GUID, servicePlan.GUID, `{"size":1,"ha":false}`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServiceInstanceExists(ref),
+						func(s *terraform.State) error {
+							id = s.RootModule().Resources[ref].Primary.ID
+							return nil
+						},
+					),
+				},
+				{
+					// the same params written differently do not plan any change
+					Config:   fmt.Sprintf(src, "tf-acc-rename", space.GUID, servicePlan.GUID, `{ "ha": false, "size": 1 }`),
+					PlanOnly: true,
+				},
+				{
+					Config: fmt.Sprintf(src, "tf-acc-renamed", space.GUID, servicePlan.GUID, `{"size":1,"ha":false}`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServiceInstanceExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-renamed"),
+						func(s *terraform.State) error {
+							if renamed := s.RootModule().Resources[ref].Primary.ID; renamed != id {
+								return fmt.Errorf("expected service instance %s renamed in place, got %s", id, renamed)
+							}
+							return nil
+						},
+					),
+				},
+				{
+					ResourceName:            ref,
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"params", "unshare_on_delete", "auto_upgrade"},
+				},
+			},
+		},
+	)
+}
+
+func TestAccResServiceInstance_shared(t *testing.T) {
+
+	srcSpace := `
+		resource "cloudfoundry_space" "shared" {
+			name = "tf-acc-shared-instance"
+			org = %q
+		}
+	`
+	src := srcSpace + `
+		resource "cloudfoundry_service_instance" "shared" {
+			name = "shared"
+			space_id = %q
+			service_plan_id = %q
+			shared_space_ids = [ %s ]
+			unshare_on_delete = %t
+		}
+	`
+
+	space := testAccEnv.Space
+	servicePlan := testAccEnv.ServicePlan
+
+	ref := "cloudfoundry_service_instance.shared"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckServiceInstanceDestroyed([]string{"shared"}, ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, testAccEnv.Organization.GUID, space.GUID, servicePlan.GUID, "cloudfoundry_space.shared.id", false),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServiceInstanceExists(ref),
+						resource.TestCheckResourceAttr(ref, "shared_space_ids.#", "1"),
+						testAccCheckServiceInstanceSharedSpaces(ref, 1),
+						resource.TestCheckResourceAttr(ref, "upgrade_available", "false"),
+						resource.TestCheckResourceAttrPair(ref, "maintenance_info_version", ref, "maintenance_info.0.version"),
+					),
+				},
+				{
+					Config:      fmt.Sprintf(srcSpace, testAccEnv.Organization.GUID),
+					ExpectError: regexp.MustCompile("is shared with spaces .*, unshare it or set unshare_on_delete"),
+				},
+				{
+					Config: fmt.Sprintf(src, testAccEnv.Organization.GUID, space.GUID, servicePlan.GUID, "cloudfoundry_space.shared.id", true),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServiceInstanceExists(ref),
+						testAccCheckServiceInstanceSharedSpaces(ref, 1),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, testAccEnv.Organization.GUID, space.GUID, servicePlan.GUID, "", true),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(ref, "shared_space_ids.#", "0"),
+						testAccCheckServiceInstanceSharedSpaces(ref, 0),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, testAccEnv.Organization.GUID, space.GUID, servicePlan.GUID, "cloudfoundry_space.shared.id", true),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServiceInstanceSharedSpaces(ref, 1),
+					),
+				},
+			},
+		},
+	)
+}
+
+func TestAccResServiceInstance_upgradeConflict(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_service_instance" "upgrade" {
+			name = "upgrade"
+			space_id = %q
+			service_plan_id = %q
+			auto_upgrade = true
+			maintenance_info_version = "1.0.0"
+		}
+	`
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(src, testAccEnv.Space.GUID, testAccEnv.ServicePlan.GUID),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile("conflicts with"),
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckServiceInstanceSharedSpaces(resource string, count int) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("service instance '%s' not found in terraform state", resource)
+		}
+
+		shared, _, err := testAccEnv.Session.ClientV3.GetServiceInstanceSharedSpaces(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if len(shared) != count {
+			return fmt.Errorf("expected service instance shared with %d spaces, got %d", count, len(shared))
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckServiceInstanceExists(resource string) resource.TestCheckFunc {
 
 	return func(s *terraform.State) error {
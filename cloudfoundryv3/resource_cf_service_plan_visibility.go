@@ -0,0 +1,302 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+const (
+	visibilityModeAuthoritative = "authoritative"
+	visibilityModeAdditive      = "additive"
+)
+
+func resourceServicePlanVisibility() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "visibility of a service plan in the marketplace, the plan is only visible to admins again once destroyed",
+
+		CreateContext: resourceServicePlanVisibilityCreate,
+		ReadContext:   resourceServicePlanVisibilityRead,
+		UpdateContext: resourceServicePlanVisibilityUpdate,
+		DeleteContext: resourceServicePlanVisibilityDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceServicePlanVisibilityRead),
+		},
+
+		CustomizeDiff: servicePlanVisibilityDiff,
+
+		Schema: map[string]*schema.Schema{
+
+			"service_plan": {
+				Description:  "GUID of the service plan, or set service_offering_name and service_plan_name",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"service_plan", "service_plan_name"},
+			},
+
+			"service_offering_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"service_plan_name"},
+			},
+
+			"service_plan_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"service_offering_name"},
+			},
+
+			"service_broker_name": {
+				Description:  "Name of the broker of the offering, when several brokers register an offering with the same name",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"service_plan_name"},
+			},
+
+			"type": {
+				Description: "public, admin, organization or space, space is the fixed visibility of the plans of space-scoped brokers",
+				Type:        schema.TypeString,
+				Required:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(resources.ServicePlanVisibilityPublic),
+					string(resources.ServicePlanVisibilityAdmin),
+					string(resources.ServicePlanVisibilityOrganization),
+					string(resources.ServicePlanVisibilitySpace),
+				}, false),
+			},
+
+			"orgs": {
+				Description: "GUIDs of the organizations the plan is visible in, with type organization",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"mode": {
+				Description:  "authoritative removes the organizations not listed, additive leaves them untouched",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      visibilityModeAuthoritative,
+				ValidateFunc: validation.StringInSlice([]string{visibilityModeAuthoritative, visibilityModeAdditive}, false),
+			},
+
+			"space": {
+				Description: "GUID of the space of the plan of a space-scoped broker",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func servicePlanVisibilityDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	visibilityType := d.Get("type").(string)
+	if visibilityType != string(resources.ServicePlanVisibilityOrganization) && d.Get("orgs").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("orgs can only be set with type %s", resources.ServicePlanVisibilityOrganization)
+	}
+	if visibilityType != string(resources.ServicePlanVisibilityOrganization) && d.Get("mode").(string) == visibilityModeAdditive {
+		return fmt.Errorf("mode %s can only be set with type %s", visibilityModeAdditive, resources.ServicePlanVisibilityOrganization)
+	}
+	return nil
+}
+
+func resourceServicePlanVisibilityCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	plan := d.Get("service_plan").(string)
+	if plan == "" {
+		p, planDiags := lookupServicePlan(session,
+			d.Get("service_offering_name").(string),
+			d.Get("service_plan_name").(string),
+			d.Get("service_broker_name").(string),
+		)
+		diags = append(diags, planDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		plan = p.GUID
+	}
+
+	diags = append(diags, updateServicePlanVisibility(session, plan, d.Get("type").(string), d.Get("mode").(string), nil, d.Get("orgs").(*schema.Set))...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(plan)
+
+	return append(diags, resourceServicePlanVisibilityRead(ctx, d, meta)...)
+}
+
+func resourceServicePlanVisibilityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	visibility, warns, err := session.ClientV3.GetServicePlanVisibility(d.Id())
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-service-plan-visibility", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	guids := make([]string, 0, len(visibility.Organizations))
+	for _, org := range visibility.Organizations {
+		guids = append(guids, org.GUID)
+	}
+	orgs := schema.NewSet(schema.HashString, nil)
+	if d.Get("mode").(string) == visibilityModeAdditive {
+		orgs = managedGUIDs(d.Get("orgs").(*schema.Set), guids)
+	} else {
+		for _, guid := range guids {
+			orgs.Add(guid)
+		}
+	}
+
+	if IsImportState(d) {
+		_ = d.Set("mode", visibilityModeAuthoritative)
+	}
+	_ = d.Set("service_plan", d.Id())
+	_ = d.Set("type", string(visibility.Type))
+	_ = d.Set("orgs", orgs)
+	_ = d.Set("space", visibility.Space.GUID)
+
+	return diags
+}
+
+func resourceServicePlanVisibilityUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if d.HasChanges("type", "orgs", "mode") {
+		o, n := d.GetChange("orgs")
+		diags = append(diags, updateServicePlanVisibility(session, d.Id(), d.Get("type").(string), d.Get("mode").(string), o.(*schema.Set), n.(*schema.Set))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceServicePlanVisibilityRead(ctx, d, meta)...)
+}
+
+func resourceServicePlanVisibilityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	switch {
+	case d.Get("type").(string) == string(resources.ServicePlanVisibilitySpace):
+		// the visibility of the plans of space-scoped brokers cannot change
+		return diags
+	case d.Get("mode").(string) == visibilityModeAdditive:
+		for _, org := range setToStrings(d.Get("orgs").(*schema.Set)) {
+			warns, err := session.ClientV3.DeleteServicePlanVisibility(d.Id(), org)
+			if IsErrNotFound(err) {
+				continue
+			}
+			diags = append(diags, diagFromClient("delete-service-plan-visibility", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		return diags
+	}
+
+	_, warns, err := rawRequest(session, "PATCH", "/v3/service_plans/"+d.Id()+"/visibility", resources.ServicePlanVisibility{
+		Type: resources.ServicePlanVisibilityAdmin,
+	}, nil)
+	if IsErrNotFound(err) {
+		return diags
+	}
+	return append(diags, diagFromClient("update-service-plan-visibility", warns, err)...)
+}
+
+// updateServicePlanVisibility replaces the visibility of the plan, or only
+// adds and removes the organizations that changed in additive mode
+func updateServicePlanVisibility(session *managers.Session, plan, visibilityType, mode string, oldOrgs, newOrgs *schema.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if visibilityType == string(resources.ServicePlanVisibilitySpace) {
+		visibility, warns, err := session.ClientV3.GetServicePlanVisibility(plan)
+		diags = append(diags, diagFromClient("get-service-plan-visibility", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		if visibility.Type != resources.ServicePlanVisibilitySpace {
+			return append(diags, diag.FromErr(fmt.Errorf("type %s is only available to the plans of space-scoped brokers", visibilityType))...)
+		}
+		return diags
+	}
+
+	if mode == visibilityModeAdditive {
+		if oldOrgs == nil {
+			oldOrgs = schema.NewSet(schema.HashString, nil)
+		}
+		for _, org := range setToStrings(oldOrgs.Difference(newOrgs)) {
+			warns, err := session.ClientV3.DeleteServicePlanVisibility(plan, org)
+			if IsErrNotFound(err) {
+				continue
+			}
+			diags = append(diags, diagFromClient("delete-service-plan-visibility", warns, err)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		added := setToStrings(newOrgs.Difference(oldOrgs))
+		if len(added) == 0 {
+			return diags
+		}
+		_, warns, err := session.ClientV3.UpdateServicePlanVisibility(plan, servicePlanVisibility(visibilityType, added))
+		return append(diags, diagFromClient("add-service-plan-visibility", warns, err)...)
+	}
+
+	// the ccv3 client only appends organizations, a patch replaces them
+	_, warns, err := rawRequest(session, "PATCH", "/v3/service_plans/"+plan+"/visibility",
+		servicePlanVisibility(visibilityType, setToStrings(newOrgs)), nil)
+	return append(diags, diagFromClient("update-service-plan-visibility", warns, err)...)
+}
+
+func servicePlanVisibility(visibilityType string, orgs []string) resources.ServicePlanVisibility {
+	visibility := resources.ServicePlanVisibility{
+		Type: resources.ServicePlanVisibilityType(visibilityType),
+	}
+	for _, org := range orgs {
+		visibility.Organizations = append(visibility.Organizations, resources.ServicePlanVisibilityDetail{GUID: org})
+	}
+	return visibility
+}
+
+// lookupServicePlan finds a plan by its offering and plan names, the broker
+// name is only needed when several brokers register the same offering
+func lookupServicePlan(session *managers.Session, offering, plan, broker string, filters ...ccv3.Query) (resources.ServicePlan, diag.Diagnostics) {
+	query := append([]ccv3.Query{
+		{Key: ccv3.ServiceOfferingNamesFilter, Values: []string{offering}},
+		{Key: ccv3.NameFilter, Values: []string{plan}},
+	}, filters...)
+	if broker != "" {
+		query = append(query, ccv3.Query{Key: ccv3.ServiceBrokerNamesFilter, Values: []string{broker}})
+	}
+
+	plans, warns, err := session.ClientV3.GetServicePlans(query...)
+	diags := diagFromClient("get-service-plans", warns, err)
+	if diags.HasError() {
+		return resources.ServicePlan{}, diags
+	}
+	switch len(plans) {
+	case 0:
+		return resources.ServicePlan{}, append(diags, diag.FromErr(fmt.Errorf("service plan %s of offering %s not found", plan, offering))...)
+	case 1:
+		return plans[0], diags
+	}
+	return resources.ServicePlan{}, append(diags, diag.FromErr(fmt.Errorf("service plan %s of offering %s is registered by several brokers, set the broker name", plan, offering))...)
+}
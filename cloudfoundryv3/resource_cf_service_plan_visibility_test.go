@@ -0,0 +1,116 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResServicePlanVisibility_normal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_org" "visibility" {
+			name = "tf-acc-plan-visibility"
+		}
+		resource "cloudfoundry_service_plan_visibility" "plan" {
+			service_offering_name = %q
+			service_plan_name = %q
+			service_broker_name = %q
+			type = %q
+			%s
+		}
+	`
+	offering := testAccEnv.ServiceOffering.Name
+	plan := testAccEnv.ServicePlan.Name
+	broker := testAccEnv.ServiceBroker.Name
+
+	ref := "cloudfoundry_service_plan_visibility.plan"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckServicePlanVisibilityType(ref, resources.ServicePlanVisibilityAdmin),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, offering, plan, broker, "organization", `orgs = [ cloudfoundry_org.visibility.id ]`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServicePlanVisibilityType(ref, resources.ServicePlanVisibilityOrganization),
+						resource.TestCheckResourceAttr(ref, "service_plan", testAccEnv.ServicePlan.GUID),
+						resource.TestCheckResourceAttr(ref, "orgs.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, offering, plan, broker, "organization", `orgs = [ cloudfoundry_org.visibility.id ]
+						mode = "additive"`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServicePlanVisibilityType(ref, resources.ServicePlanVisibilityOrganization),
+						resource.TestCheckResourceAttr(ref, "mode", "additive"),
+						resource.TestCheckResourceAttr(ref, "orgs.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, offering, plan, broker, "public", ""),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckServicePlanVisibilityType(ref, resources.ServicePlanVisibilityPublic),
+						resource.TestCheckResourceAttr(ref, "orgs.#", "0"),
+					),
+				},
+				{
+					ResourceName:            ref,
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"service_offering_name", "service_plan_name", "service_broker_name"},
+				},
+			},
+		},
+	)
+}
+
+func TestAccResServicePlanVisibility_invalidOrgs(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_service_plan_visibility" "plan" {
+			service_plan = %q
+			type = "public"
+			orgs = [ %q ]
+		}
+	`
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      fmt.Sprintf(src, testAccEnv.ServicePlan.GUID, testAccEnv.Organization.GUID),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile("orgs can only be set with type organization"),
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckServicePlanVisibilityType(resource string, visibilityType resources.ServicePlanVisibilityType) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("service plan visibility '%s' not found in terraform state", resource)
+		}
+
+		visibility, _, err := testAccEnv.Session.ClientV3.GetServicePlanVisibility(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if visibility.Type != visibilityType {
+			return fmt.Errorf("expected visibility %s, got %s", visibilityType, visibility.Type)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,224 @@
+package cloudfoundry
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+const sshSpaceFeature = "ssh"
+
+func resourceSpace() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "space within an organization, renaming is done in place",
+
+		CreateContext: resourceSpaceCreate,
+		ReadContext:   resourceSpaceRead,
+		UpdateContext: resourceSpaceUpdate,
+		DeleteContext: resourceSpaceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceSpaceRead),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"org": {
+				Description: "GUID of the organization owning the space",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"quota": {
+				Description: "GUID of the space quota, the space is only bound by the organization quota when not set",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"allow_ssh": {
+				Description: "Allow SSH access to the apps of the space",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"isolation_segment": {
+				Description: "GUID of the isolation segment the apps of the space run on, the organization default is used when not set",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			labelsKey:      labelsSchema(),
+			annotationsKey: annotationsSchema(),
+		},
+	}
+}
+
+func resourceSpaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	space, warns, err := session.ClientV3.CreateSpace(resources.Space{
+		Name: d.Get("name").(string),
+		Relationships: resources.Relationships{
+			constant.RelationshipTypeOrganization: resources.Relationship{GUID: d.Get("org").(string)},
+		},
+	})
+	diags = append(diags, diagFromClient("create-space", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(space.GUID)
+
+	if quota, ok := d.GetOk("quota"); ok {
+		_, warns, err = session.ClientV3.ApplySpaceQuota(quota.(string), space.GUID)
+		diags = append(diags, diagFromClient("apply-space-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if allowSSH, ok := d.GetOkExists("allow_ssh"); ok {
+		warns, err = session.ClientV3.UpdateSpaceFeature(space.GUID, allowSSH.(bool), sshSpaceFeature)
+		diags = append(diags, diagFromClient("update-space-ssh", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if segment, ok := d.GetOk("isolation_segment"); ok {
+		_, warns, err = session.ClientV3.UpdateSpaceIsolationSegmentRelationship(space.GUID, segment.(string))
+		diags = append(diags, diagFromClient("update-space-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, metadataUpdate(spaceMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceSpaceRead(ctx, d, meta)...)
+}
+
+func resourceSpaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	spaces, _, warns, err := session.ClientV3.GetSpaces(
+		ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{d.Id()}},
+	)
+	diags = append(diags, diagFromClient("get-space", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(spaces) == 0 {
+		d.SetId("")
+		return diags
+	}
+	space := spaces[0]
+
+	_ = d.Set("name", space.Name)
+	_ = d.Set("org", space.Relationships[constant.RelationshipTypeOrganization].GUID)
+	_ = d.Set("quota", space.Relationships[constant.RelationshipTypeQuota].GUID)
+
+	allowSSH, warns, err := session.ClientV3.GetSpaceFeature(space.GUID, sshSpaceFeature)
+	diags = append(diags, diagFromClient("get-space-ssh", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	_ = d.Set("allow_ssh", allowSSH)
+
+	segment, warns, err := session.ClientV3.GetSpaceIsolationSegment(space.GUID)
+	diags = append(diags, diagFromClient("get-space-isolation-segment", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	_ = d.Set("isolation_segment", segment.GUID)
+
+	return append(diags, metadataRead(spaceMetadata, d, meta, false)...)
+}
+
+func resourceSpaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if d.HasChange("name") {
+		_, warns, err := session.ClientV3.UpdateSpace(resources.Space{
+			GUID: d.Id(),
+			Name: d.Get("name").(string),
+		})
+		diags = append(diags, diagFromClient("update-space", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("quota") {
+		o, n := d.GetChange("quota")
+		if n.(string) != "" {
+			_, warns, err := session.ClientV3.ApplySpaceQuota(n.(string), d.Id())
+			diags = append(diags, diagFromClient("apply-space-quota", warns, err)...)
+		} else {
+			warns, err := session.ClientV3.UnsetSpaceQuota(o.(string), d.Id())
+			diags = append(diags, diagFromClient("unset-space-quota", warns, err)...)
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("allow_ssh") {
+		warns, err := session.ClientV3.UpdateSpaceFeature(d.Id(), d.Get("allow_ssh").(bool), sshSpaceFeature)
+		diags = append(diags, diagFromClient("update-space-ssh", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("isolation_segment") {
+		// an empty guid resets the space to the organization default
+		_, warns, err := session.ClientV3.UpdateSpaceIsolationSegmentRelationship(d.Id(), d.Get("isolation_segment").(string))
+		diags = append(diags, diagFromClient("update-space-isolation-segment", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		diags = append(diags, appsToRestart(session, "the isolation segment of the space changed", d.Id())...)
+	}
+
+	diags = append(diags, metadataUpdate(spaceMetadata, d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceSpaceRead(ctx, d, meta)...)
+}
+
+func resourceSpaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	jobURL, warns, err := session.ClientV3.DeleteSpace(d.Id())
+	diags = append(diags, diagFromClient("delete-space", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, waitJob(ctx, session, jobURL, d.Timeout(schema.TimeoutDelete))...)
+}
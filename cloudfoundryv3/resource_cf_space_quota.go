@@ -0,0 +1,176 @@
+package cloudfoundry
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceSpaceQuota() *schema.Resource {
+
+	s := quotaSchema(false, false)
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.NoZeroValues,
+	}
+	s["org"] = &schema.Schema{
+		Description: "GUID of the organization owning the quota",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+	s["spaces"] = &schema.Schema{
+		Description: "GUIDs of the spaces the quota is applied to",
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+	}
+
+	return &schema.Resource{
+		Description: "space quota of an organization, limits are unlimited when set to -1",
+
+		CreateContext: resourceSpaceQuotaCreate,
+		ReadContext:   resourceSpaceQuotaRead,
+		UpdateContext: resourceSpaceQuotaUpdate,
+		DeleteContext: resourceSpaceQuotaDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceSpaceQuotaRead),
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceSpaceQuotaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	payload := quotaPayload(d, false)
+	payload["relationships"] = map[string]interface{}{
+		"organization": resources.Relationship{GUID: d.Get("org").(string)},
+	}
+
+	var q quota
+	_, warns, err := rawRequest(session, "POST", "/v3/space_quotas", payload, &q)
+	diags = append(diags, diagFromClient("create-space-quota", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(q.GUID)
+
+	if spaces := setToStrings(d.Get("spaces").(*schema.Set)); len(spaces) > 0 {
+		diags = append(diags, applySpaceQuota(session, d.Id(), spaces)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceSpaceQuotaRead(ctx, d, meta)...)
+}
+
+func resourceSpaceQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	var q quota
+	_, warns, err := rawRequest(session, "GET", "/v3/space_quotas/"+d.Id(), nil, &q)
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	diags = append(diags, diagFromClient("get-space-quota", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	quotaToResource(q, d, false)
+	_ = d.Set("org", q.Relationships.Organization.GUID)
+	_ = d.Set("spaces", managedGUIDs(d.Get("spaces").(*schema.Set), q.Relationships.Spaces.GUIDs))
+
+	return diags
+}
+
+func resourceSpaceQuotaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	if quotaChanged(d, false) {
+		_, warns, err := rawRequest(session, "PATCH", "/v3/space_quotas/"+d.Id(), quotaPayload(d, false), nil)
+		diags = append(diags, diagFromClient("update-space-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("spaces") {
+		o, n := d.GetChange("spaces")
+		added := setToStrings(n.(*schema.Set).Difference(o.(*schema.Set)))
+		removed := setToStrings(o.(*schema.Set).Difference(n.(*schema.Set)))
+
+		if len(added) > 0 {
+			diags = append(diags, applySpaceQuota(session, d.Id(), added)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		if len(removed) > 0 {
+			diags = append(diags, unapplySpaceQuota(session, d.Id(), removed)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return append(diags, resourceSpaceQuotaRead(ctx, d, meta)...)
+}
+
+func resourceSpaceQuotaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+
+	// a quota in use cannot be deleted
+	if spaces := setToStrings(d.Get("spaces").(*schema.Set)); len(spaces) > 0 {
+		diags = append(diags, unapplySpaceQuota(session, d.Id(), spaces)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	jobURL, warns, err := session.ClientV3.DeleteSpaceQuota(d.Id())
+	diags = append(diags, diagFromClient("delete-space-quota", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	warns, err = session.ClientV3.PollJob(jobURL)
+	return append(diags, diagFromClient("poll-delete-space-quota-job", warns, err)...)
+}
+
+func applySpaceQuota(session *managers.Session, quotaGUID string, spaces []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, space := range spaces {
+		_, warns, err := session.ClientV3.ApplySpaceQuota(quotaGUID, space)
+		diags = append(diags, diagFromClient("apply-space-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
+
+func unapplySpaceQuota(session *managers.Session, quotaGUID string, spaces []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, space := range spaces {
+		warns, err := session.ClientV3.UnsetSpaceQuota(quotaGUID, space)
+		if IsErrNotFound(err) {
+			continue
+		}
+		diags = append(diags, diagFromClient("unset-space-quota", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
@@ -0,0 +1,96 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResSpaceQuota_normal(t *testing.T) {
+
+	org := testAccEnv.Organization
+
+	src := `
+		resource "cloudfoundry_space" "quota" {
+			name = "tf-acc-space-quota"
+			org = %q
+		}
+		resource "cloudfoundry_space_quota" "q1" {
+			name = "tf-acc-space-quota"
+			org = %q
+			total_memory_in_mb = 1024
+			per_app_tasks = %d
+			spaces = [ %s ]
+		}
+	`
+
+	ref := "cloudfoundry_space_quota.q1"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckSpaceQuotaDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, org.GUID, org.GUID, 5, "cloudfoundry_space.quota.id"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckSpaceQuotaExists(ref),
+						resource.TestCheckResourceAttr(ref, "per_app_tasks", "5"),
+						resource.TestCheckResourceAttr(ref, "spaces.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, org.GUID, org.GUID, -1, ""),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckSpaceQuotaExists(ref),
+						resource.TestCheckResourceAttr(ref, "per_app_tasks", "-1"),
+						resource.TestCheckResourceAttr(ref, "spaces.#", "0"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckSpaceQuotaExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("space quota '%s' not found in terraform state", resource)
+		}
+
+		_, _, err := testAccEnv.Session.ClientV3.GetSpaceQuota(rs.Primary.ID)
+		return err
+	}
+}
+
+func testAccCheckSpaceQuotaDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		quotas, _, err := testAccEnv.Session.ClientV3.GetSpaceQuotas(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(quotas) > 0 {
+			return fmt.Errorf("space quota %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
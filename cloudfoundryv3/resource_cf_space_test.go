@@ -0,0 +1,113 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResSpace_normal(t *testing.T) {
+
+	org := testAccEnv.Organization
+
+	src := `
+		resource "cloudfoundry_space" "space1" {
+			name = %q
+			org = %q
+			allow_ssh = %t
+			labels = {
+				env = "dev"
+			}
+		}
+	`
+
+	ref := "cloudfoundry_space.space1"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckSpaceDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, "tf-acc-space", org.GUID, false),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckSpaceExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-space"),
+						resource.TestCheckResourceAttr(ref, "org", org.GUID),
+						resource.TestCheckResourceAttr(ref, "allow_ssh", "false"),
+						resource.TestCheckResourceAttr(ref, "quota", ""),
+						resource.TestCheckResourceAttr(ref, "labels.env", "dev"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, "tf-acc-space-renamed", org.GUID, true),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckSpaceExists(ref),
+						resource.TestCheckResourceAttr(ref, "name", "tf-acc-space-renamed"),
+						resource.TestCheckResourceAttr(ref, "allow_ssh", "true"),
+					),
+				},
+				{
+					ResourceName:      ref,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckSpaceExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("space '%s' not found in terraform state", resource)
+		}
+
+		spaces, _, _, err := testAccEnv.Session.ClientV3.GetSpaces(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(spaces) != 1 {
+			return fmt.Errorf("space %s not found", rs.Primary.ID)
+		}
+
+		allowSSH, _, err := testAccEnv.Session.ClientV3.GetSpaceFeature(rs.Primary.ID, "ssh")
+		if err != nil {
+			return err
+		}
+		if fmt.Sprintf("%t", allowSSH) != rs.Primary.Attributes["allow_ssh"] {
+			return fmt.Errorf("expected allow_ssh %s, got %t", rs.Primary.Attributes["allow_ssh"], allowSSH)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSpaceDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		spaces, _, _, err := testAccEnv.Session.ClientV3.GetSpaces(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(spaces) > 0 {
+			return fmt.Errorf("space %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
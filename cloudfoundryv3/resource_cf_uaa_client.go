@@ -0,0 +1,267 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	uaaapi "github.com/cloudfoundry-community/go-uaa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+var uaaGrantTypes = []string{
+	string(uaaapi.AUTHCODE),
+	string(uaaapi.IMPLICIT),
+	string(uaaapi.PASSWORD),
+	string(uaaapi.CLIENTCREDENTIALS),
+	string(uaaapi.REFRESHTOKEN),
+	"urn:ietf:params:oauth:grant-type:jwt-bearer",
+	"urn:ietf:params:oauth:grant-type:saml2-bearer",
+	"user_token",
+}
+
+func resourceUAAClient() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "uaa oauth client, requires uaa_client_id on the provider",
+
+		CreateContext: resourceUAAClientCreate,
+		ReadContext:   resourceUAAClientRead,
+		UpdateContext: resourceUAAClientUpdate,
+		DeleteContext: resourceUAAClientDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceUAAClientRead),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"client_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"client_secret": {
+				Description: "Secret of the client, it is never read back and is rotated in place",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"name": {
+				Description: "Display name of the client",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"authorized_grant_types": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(uaaGrantTypes, false),
+				},
+				Set: schema.HashString,
+			},
+
+			"scope": {
+				Description: "Scopes the client can request on behalf of users, uaa sets uaa.none when empty",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"authorities": {
+				Description: "Scopes the client gets with the client_credentials grant, uaa sets uaa.none when empty",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"redirect_uri": {
+				Description: "Allowed redirect URIs, required by the authorization_code and implicit grants",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"auto_approve": {
+				Description: "Scopes approved without asking the user, [\"true\"] approves all scopes",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"access_token_validity": {
+				Description:  "Validity of access tokens in seconds, the zone default is used when not set",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"refresh_token_validity": {
+				Description:  "Validity of refresh tokens in seconds, the zone default is used when not set",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourceUAAClientCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	client := uaaClientFromResource(d)
+	client.ClientSecret = d.Get("client_secret").(string)
+	if err := client.Validate(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	err := uaaRequest(api, "POST", uaaapi.ClientsEndpoint, client, nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("create uaa client %s: %s", client.ClientID, err))
+	}
+	d.SetId(client.ClientID)
+
+	return append(diags, resourceUAAClientRead(ctx, d, meta)...)
+}
+
+func resourceUAAClientRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	var client uaaapi.Client
+	err := uaaRequest(api, "GET", fmt.Sprintf("%s/%s", uaaapi.ClientsEndpoint, d.Id()), nil, &client)
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get uaa client %s: %s", d.Id(), err))
+	}
+
+	_ = d.Set("client_id", client.ClientID)
+	_ = d.Set("name", client.DisplayName)
+	_ = d.Set("authorized_grant_types", client.AuthorizedGrantTypes)
+	_ = d.Set("scope", client.Scope)
+	_ = d.Set("authorities", client.Authorities)
+	_ = d.Set("redirect_uri", client.RedirectURI)
+	_ = d.Set("auto_approve", uaaAutoApprove(client.AutoApproveRaw))
+	_ = d.Set("access_token_validity", client.AccessTokenValidity)
+	_ = d.Set("refresh_token_validity", client.RefreshTokenValidity)
+
+	return diags
+}
+
+func resourceUAAClientUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.HasChanges("name", "authorized_grant_types", "scope", "authorities", "redirect_uri", "auto_approve", "access_token_validity", "refresh_token_validity") {
+		client := uaaClientFromResource(d)
+		err := uaaRequest(api, "PUT", fmt.Sprintf("%s/%s", uaaapi.ClientsEndpoint, d.Id()), client, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("update uaa client %s: %s", d.Id(), err))
+		}
+	}
+
+	// the update endpoint ignores the secret, it has a dedicated endpoint
+	if d.HasChange("client_secret") {
+		err := api.ChangeClientSecret(d.Id(), d.Get("client_secret").(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("change secret of uaa client %s: %s", d.Id(), err))
+		}
+	}
+
+	return append(diags, resourceUAAClientRead(ctx, d, meta)...)
+}
+
+func resourceUAAClientDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	err := uaaRequest(api, "DELETE", fmt.Sprintf("%s/%s", uaaapi.ClientsEndpoint, d.Id()), nil, nil)
+	if err != nil && !IsErrNotFound(err) {
+		return diag.FromErr(fmt.Errorf("delete uaa client %s: %s", d.Id(), err))
+	}
+	return diags
+}
+
+func uaaClientFromResource(d *schema.ResourceData) uaaapi.Client {
+	client := uaaapi.Client{
+		ClientID:             d.Get("client_id").(string),
+		DisplayName:          d.Get("name").(string),
+		AuthorizedGrantTypes: setToStrings(d.Get("authorized_grant_types").(*schema.Set)),
+		Scope:                setToStrings(d.Get("scope").(*schema.Set)),
+		Authorities:          setToStrings(d.Get("authorities").(*schema.Set)),
+		RedirectURI:          setToStrings(d.Get("redirect_uri").(*schema.Set)),
+		AccessTokenValidity:  int64(d.Get("access_token_validity").(int)),
+		RefreshTokenValidity: int64(d.Get("refresh_token_validity").(int)),
+	}
+
+	autoApprove := setToStrings(d.Get("auto_approve").(*schema.Set))
+	if len(autoApprove) == 1 && autoApprove[0] == "true" {
+		client.AutoApproveRaw = true
+	} else if len(autoApprove) > 0 {
+		client.AutoApproveRaw = autoApprove
+	} else {
+		// an empty list is dropped by omitempty, removing all scopes needs
+		// an explicit value
+		client.AutoApproveRaw = false
+	}
+	return client
+}
+
+// uaaAutoApprove converts the autoapprove field which uaa returns either as
+// a boolean or as a list of scopes
+func uaaAutoApprove(raw interface{}) []string {
+	switch t := raw.(type) {
+	case bool:
+		if t {
+			return []string{strconv.FormatBool(t)}
+		}
+	case string:
+		if t == "true" {
+			return []string{t}
+		}
+	case []interface{}:
+		scopes := make([]string, 0, len(t))
+		for _, s := range t {
+			scopes = append(scopes, fmt.Sprint(s))
+		}
+		return scopes
+	}
+	return []string{}
+}
+
+func setToStrings(set *schema.Set) []string {
+	res := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		res = append(res, v.(string))
+	}
+	return res
+}
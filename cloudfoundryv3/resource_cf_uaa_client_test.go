@@ -0,0 +1,96 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func TestAccResUAAClient_normal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_uaa_client" "exporter" {
+			client_id = "tf-acc-exporter"
+			client_secret = %q
+			authorized_grant_types = [ "client_credentials" ]
+			authorities = [ %s ]
+			access_token_validity = 3600
+		}
+	`
+
+	ref := "cloudfoundry_uaa_client.exporter"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheckUAA(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckUAAClientDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, "secret-1", `"doppler.firehose"`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckUAAClientExists(ref),
+						resource.TestCheckResourceAttr(ref, "authorities.#", "1"),
+						resource.TestCheckResourceAttr(ref, "access_token_validity", "3600"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, "secret-2", `"doppler.firehose", "cloud_controller.admin_read_only"`),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckUAAClientExists(ref),
+						resource.TestCheckResourceAttr(ref, "authorities.#", "2"),
+						resource.TestCheckResourceAttr(ref, "client_secret", "secret-2"),
+					),
+				},
+				{
+					ResourceName:            ref,
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"client_secret"},
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckUAAClientExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		session := testAccProvider.Meta().(*managers.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("uaa client '%s' not found in terraform state", resource)
+		}
+
+		client, err := session.ClientUAAAPI.GetClient(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if fmt.Sprint(len(client.Authorities)) != rs.Primary.Attributes["authorities.#"] {
+			return fmt.Errorf("expected %s authorities, got %v", rs.Primary.Attributes["authorities.#"], client.Authorities)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckUAAClientDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		session := testAccProvider.Meta().(*managers.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		if _, err := session.ClientUAAAPI.GetClient(rs.Primary.ID); err == nil {
+			return fmt.Errorf("uaa client %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	uaaapi "github.com/cloudfoundry-community/go-uaa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+const uaaMemberTypeUser = "USER"
+
+func resourceUAAGroupMembership() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "users member of an uaa group, members added outside of terraform are left untouched",
+
+		CreateContext: resourceUAAGroupMembershipCreate,
+		ReadContext:   resourceUAAGroupMembershipRead,
+		UpdateContext: resourceUAAGroupMembershipUpdate,
+		DeleteContext: resourceUAAGroupMembershipDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUAAGroupMembershipImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"group": {
+				Description:  "Name of the uaa group, e.g. cloud_controller.admin",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"users": {
+				Description: "IDs of the users member of the group",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+	}
+}
+
+// resourceUAAGroupMembershipImport imports all the user members of a group
+// given by name
+func resourceUAAGroupMembershipImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return nil, diagsToError(diags)
+	}
+
+	group, err := api.GetGroupByName(d.Id(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]interface{}, 0, len(group.Members))
+	for _, member := range group.Members {
+		if member.Type == uaaMemberTypeUser {
+			users = append(users, member.Value)
+		}
+	}
+	d.SetId(group.ID)
+	_ = d.Set("group", group.DisplayName)
+	_ = d.Set("users", schema.NewSet(schema.HashString, users))
+
+	return ImportReadContext(resourceUAAGroupMembershipRead)(ctx, d, meta)
+}
+
+func resourceUAAGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	group, err := api.GetGroupByName(d.Get("group").(string), "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(group.ID)
+
+	members := uaaGroupUsers(group)
+	for _, user := range d.Get("users").(*schema.Set).List() {
+		if _, ok := members[user.(string)]; ok {
+			continue
+		}
+		diags = append(diags, addUAAGroupMember(api, group.ID, user.(string))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceUAAGroupMembershipRead(ctx, d, meta)...)
+}
+
+func resourceUAAGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	var group uaaapi.Group
+	err := uaaRequest(api, "GET", "/Groups/"+d.Id(), nil, &group)
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get uaa group %s: %s", d.Id(), err))
+	}
+
+	// only keep the managed users which are still members
+	members := uaaGroupUsers(&group)
+	users := make([]interface{}, 0)
+	for _, user := range d.Get("users").(*schema.Set).List() {
+		if _, ok := members[user.(string)]; ok {
+			users = append(users, user)
+		}
+	}
+	_ = d.Set("group", group.DisplayName)
+	_ = d.Set("users", schema.NewSet(schema.HashString, users))
+
+	return diags
+}
+
+func resourceUAAGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	o, n := d.GetChange("users")
+	for _, user := range o.(*schema.Set).Difference(n.(*schema.Set)).List() {
+		diags = append(diags, removeUAAGroupMember(api, d.Id(), user.(string))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	for _, user := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+		diags = append(diags, addUAAGroupMember(api, d.Id(), user.(string))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceUAAGroupMembershipRead(ctx, d, meta)...)
+}
+
+func resourceUAAGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, user := range d.Get("users").(*schema.Set).List() {
+		diags = append(diags, removeUAAGroupMember(api, d.Id(), user.(string))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
+
+// uaaGroupUsers returns the user members of the group by id
+func uaaGroupUsers(group *uaaapi.Group) map[string]uaaapi.GroupMember {
+	members := make(map[string]uaaapi.GroupMember)
+	for _, member := range group.Members {
+		if member.Type == uaaMemberTypeUser {
+			members[member.Value] = member
+		}
+	}
+	return members
+}
+
+// addUAAGroupMember adds a user, its origin is needed by uaa to resolve it
+func addUAAGroupMember(api *uaaapi.API, groupID, userID string) diag.Diagnostics {
+	var user uaaapi.User
+	err := uaaRequest(api, "GET", "/Users/"+userID, nil, &user)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get uaa user %s: %s", userID, err))
+	}
+
+	err = api.AddGroupMember(groupID, userID, uaaMemberTypeUser, user.Origin)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("add user %s to uaa group %s: %s", userID, groupID, err))
+	}
+	return nil
+}
+
+func removeUAAGroupMember(api *uaaapi.API, groupID, userID string) diag.Diagnostics {
+	err := uaaRequest(api, "DELETE", fmt.Sprintf("/Groups/%s/members/%s", groupID, userID), nil, nil)
+	if err != nil && !IsErrNotFound(err) {
+		return diag.FromErr(fmt.Errorf("remove user %s from uaa group %s: %s", userID, groupID, err))
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	uaaapi "github.com/cloudfoundry-community/go-uaa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func resourceUser() *schema.Resource {
+
+	return &schema.Resource{
+		Description: "uaa user registered in the cloud controller, requires uaa_client_id on the provider",
+
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReadContext(resourceUserRead),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+				Description:      "Username, unique within its origin",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: caseDifference,
+			},
+
+			"password": {
+				Description: "Password of a user of the uaa origin, it is never read back",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"origin": {
+				Description: "Identity provider of the user, e.g. uaa or ldap",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultUserOrigin,
+				ForceNew:    true,
+			},
+
+			"given_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"family_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"email": {
+				Description: "Primary email, defaults to the username",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func caseDifference(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	api, diags := uaaClient(session)
+	if diags.HasError() {
+		return diags
+	}
+
+	origin := d.Get("origin").(string)
+	if origin != defaultUserOrigin && d.Get("password").(string) != "" {
+		return diag.Errorf("password can only be set for users of the %s origin, %s users authenticate against their identity provider", defaultUserOrigin, origin)
+	}
+
+	// users of external origins, e.g. ldap, may already exist in uaa after
+	// their first login, they are adopted rather than duplicated, existing
+	// users of the uaa origin have to be imported
+	var user *uaaapi.User
+	var err error
+	if origin != defaultUserOrigin {
+		user, err = findUAAUser(api, d.Get("name").(string), origin)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	adopted := user != nil
+	if !adopted {
+		user, err = api.CreateUser(userFromResource(d))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("create uaa user %s: %s", d.Get("name").(string), err))
+		}
+	}
+	d.SetId(user.ID)
+
+	users, warns, err := session.ClientV3.GetUsers(
+		ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{user.ID}},
+	)
+	diags = append(diags, diagFromClient("get-users", warns, err)...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(users) == 0 {
+		_, warns, err = session.ClientV3.CreateUser(user.ID)
+		diags = append(diags, diagFromClient("create-user", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// an adopted user gets the names and email from the config
+	if adopted {
+		diags = append(diags, updateUAAUser(api, d)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceUserRead(ctx, d, meta)...)
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	var user uaaapi.User
+	err := uaaRequest(api, "GET", "/Users/"+d.Id(), nil, &user)
+	if IsErrNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get uaa user %s: %s", d.Id(), err))
+	}
+
+	_ = d.Set("name", user.Username)
+	_ = d.Set("origin", user.Origin)
+	if user.Name != nil {
+		_ = d.Set("given_name", user.Name.GivenName)
+		_ = d.Set("family_name", user.Name.FamilyName)
+	}
+	for _, email := range user.Emails {
+		if email.Primary != nil && *email.Primary || len(user.Emails) == 1 {
+			_ = d.Set("email", email.Value)
+		}
+	}
+
+	return diags
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	api, diags := uaaClient(meta.(*managers.Session))
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.HasChanges("name", "given_name", "family_name", "email") {
+		diags = append(diags, updateUAAUser(api, d)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("password") {
+		if d.Get("origin").(string) != defaultUserOrigin {
+			return append(diags, diag.Errorf("password can only be set for users of the %s origin", defaultUserOrigin)...)
+		}
+		// an admin client changes the password without the old one
+		body := map[string]string{"password": d.Get("password").(string)}
+		err := uaaRequest(api, "PUT", fmt.Sprintf("/Users/%s/password", d.Id()), body, nil)
+		if err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("change password of uaa user %s: %s", d.Id(), err))...)
+		}
+	}
+
+	return append(diags, resourceUserRead(ctx, d, meta)...)
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	session := meta.(*managers.Session)
+	api, diags := uaaClient(session)
+	if diags.HasError() {
+		return diags
+	}
+
+	jobURL, warns, err := session.ClientV3.DeleteUser(d.Id())
+	if !IsErrNotFound(err) {
+		diags = append(diags, diagFromClient("delete-user", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+		warns, err = session.ClientV3.PollJob(jobURL)
+		diags = append(diags, diagFromClient("poll-delete-user-job", warns, err)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	err = uaaRequest(api, "DELETE", "/Users/"+d.Id(), nil, nil)
+	if err != nil && !IsErrNotFound(err) {
+		return append(diags, diag.FromErr(fmt.Errorf("delete uaa user %s: %s", d.Id(), err))...)
+	}
+
+	return diags
+}
+
+func userFromResource(d *schema.ResourceData) uaaapi.User {
+	primary := true
+	email := d.Get("email").(string)
+	if email == "" {
+		email = d.Get("name").(string)
+	}
+
+	user := uaaapi.User{
+		Username: d.Get("name").(string),
+		Password: d.Get("password").(string),
+		Origin:   d.Get("origin").(string),
+		Emails:   []uaaapi.Email{{Value: email, Primary: &primary}},
+	}
+	if d.Get("given_name").(string) != "" || d.Get("family_name").(string) != "" {
+		user.Name = &uaaapi.UserName{
+			GivenName:  d.Get("given_name").(string),
+			FamilyName: d.Get("family_name").(string),
+		}
+	}
+	return user
+}
+
+// updateUAAUser replaces the user profile, uaa requires the version in
+// If-Match, the wildcard skips the optimistic lock
+func updateUAAUser(api *uaaapi.API, d *schema.ResourceData) diag.Diagnostics {
+	user := userFromResource(d)
+	user.ID = d.Id()
+	user.Password = ""
+
+	err := uaaRequest(api, "PUT", "/Users/"+d.Id(), user, nil, "If-Match: *")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("update uaa user %s: %s", d.Id(), err))
+	}
+	return nil
+}
+
+// findUAAUser returns the user with the given name and origin or nil
+func findUAAUser(api *uaaapi.API, username, origin string) (*uaaapi.User, error) {
+	filter := fmt.Sprintf(`userName eq "%s" and origin eq "%s"`, uaaFilterValue(username), uaaFilterValue(origin))
+	users, err := api.ListAllUsers(filter, "", "", "")
+	if err != nil {
+		return nil, fmt.Errorf("list uaa users: %s", err)
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	return &users[0], nil
+}
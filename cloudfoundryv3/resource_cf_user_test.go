@@ -0,0 +1,126 @@
+package cloudfoundry_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+func testAccPreCheckUAA(t *testing.T) {
+	testAccPreCheck(t)()
+	if os.Getenv("CF_UAA_CLIENT_ID") == "" {
+		t.Skip("CF_UAA_CLIENT_ID must be set for uaa acceptance tests")
+	}
+}
+
+func TestAccResUser_normal(t *testing.T) {
+
+	src := `
+		resource "cloudfoundry_user" "u1" {
+			name = "tf-acc-user@example.com"
+			password = "Passw0rd-tf"
+			given_name = %q
+			family_name = "Doe"
+		}
+		resource "cloudfoundry_uaa_group_membership" "network" {
+			group = "network.write"
+			users = [ cloudfoundry_user.u1.id ]
+		}
+	`
+
+	ref := "cloudfoundry_user.u1"
+	refGroup := "cloudfoundry_uaa_group_membership.network"
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheckUAA(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckUserDestroyed(ref),
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(src, "John"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckUserExists(ref),
+						resource.TestCheckResourceAttr(ref, "origin", "uaa"),
+						resource.TestCheckResourceAttr(ref, "given_name", "John"),
+						resource.TestCheckResourceAttr(ref, "email", "tf-acc-user@example.com"),
+						resource.TestCheckResourceAttr(refGroup, "users.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(src, "Jane"),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckUserExists(ref),
+						resource.TestCheckResourceAttr(ref, "given_name", "Jane"),
+					),
+				},
+				{
+					ResourceName:            ref,
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"password"},
+				},
+			},
+		},
+	)
+}
+
+func testAccCheckUserExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		session := testAccProvider.Meta().(*managers.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("user '%s' not found in terraform state", resource)
+		}
+
+		user, err := session.ClientUAAAPI.GetUser(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if user.Name == nil || user.Name.GivenName != rs.Primary.Attributes["given_name"] {
+			return fmt.Errorf("expected given name %s", rs.Primary.Attributes["given_name"])
+		}
+
+		users, _, err := session.ClientV3.GetUsers(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(users) != 1 {
+			return fmt.Errorf("user %s not registered in the cloud controller", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckUserDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		session := testAccProvider.Meta().(*managers.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+
+		users, _, err := session.ClientV3.GetUsers(
+			ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{rs.Primary.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if len(users) > 0 {
+			return fmt.Errorf("user %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// portRangeParse parses a single port (8080) or a port range (8080-8090)
+func portRangeParse(portRange string) (start int, end int, err error) {
+	portRangeSplit := strings.Split(portRange, "-")
+	if len(portRangeSplit) > 2 {
+		return 0, 0, fmt.Errorf("Invalid range")
+	}
+	start, err = strconv.Atoi(strings.TrimSpace(portRangeSplit[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(portRangeSplit) == 1 {
+		return start, start, nil
+	}
+	end, err = strconv.Atoi(strings.TrimSpace(portRangeSplit[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
@@ -0,0 +1,65 @@
+package cloudfoundry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+// rawRequest performs a request on the cloud controller for endpoints or
+// fields not yet supported by the ccv3 client. The request goes through the
+// same authentication, retry and error wrappers as the ccv3 client.
+//
+// body is marshalled to json when not nil and the response is decoded into
+// result when not nil. The returned job url is the Location header sent back
+// by asynchronous operations.
+func rawRequest(s *managers.Session, method, path string, body interface{}, result interface{}) (ccv3.JobURL, ccv3.Warnings, error) {
+	var b []byte
+	if body != nil {
+		var err error
+		b, err = json.Marshal(body)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	raw, resp, err := s.ClientV3.MakeRequestSendReceiveRaw(method, s.ApiEndpoint+path, nil, b)
+	warns := rawWarnings(resp)
+	if err != nil {
+		return "", warns, err
+	}
+
+	if result != nil && len(raw) > 0 {
+		err = json.Unmarshal(raw, result)
+		if err != nil {
+			return "", warns, err
+		}
+	}
+
+	var jobURL ccv3.JobURL
+	if resp != nil {
+		jobURL = ccv3.JobURL(resp.Header.Get("Location"))
+	}
+	return jobURL, warns, nil
+}
+
+// rawWarnings decodes the comma separated warnings sent back by the cloud
+// controller in the X-Cf-Warnings header
+func rawWarnings(resp *http.Response) ccv3.Warnings {
+	var warns ccv3.Warnings
+	if resp == nil {
+		return warns
+	}
+	for _, header := range resp.Header["X-Cf-Warnings"] {
+		for _, warn := range strings.Split(header, ",") {
+			if w, err := url.QueryUnescape(strings.TrimSpace(warn)); err == nil && w != "" {
+				warns = append(warns, w)
+			}
+		}
+	}
+	return warns
+}
@@ -0,0 +1,57 @@
+package cloudfoundry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	importStateKey = "is_import_state"
+)
+
+// ImportReadContext -
+func ImportReadContext(read schema.ReadContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		MarkImportState(d)
+		diags := read(ctx, d, meta)
+		if diags.HasError() {
+			return []*schema.ResourceData{}, diagsToError(diags)
+		}
+		if d.Id() == "" {
+			return []*schema.ResourceData{}, NotFound
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// MarkImportState -
+func MarkImportState(d *schema.ResourceData) {
+	connInfo := d.ConnInfo()
+	if connInfo == nil {
+		connInfo = make(map[string]string)
+	}
+	connInfo[importStateKey] = ""
+	d.SetConnInfo(connInfo)
+}
+
+// IsImportState -
+func IsImportState(d *schema.ResourceData) bool {
+	connInfo := d.ConnInfo()
+	if connInfo == nil {
+		return false
+	}
+	_, ok := connInfo[importStateKey]
+	return ok
+}
+
+func diagsToError(diags diag.Diagnostics) error {
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s: %s", d.Detail, d.Summary)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cloudfoundry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	uaaapi "github.com/cloudfoundry-community/go-uaa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundryv3/managers"
+)
+
+// UAAStatusError is returned by uaaRequest when uaa answers with a non 2xx
+// status, go-uaa errors do not carry the status code
+type UAAStatusError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e UAAStatusError) Error() string {
+	return fmt.Sprintf("uaa %s %s failed with status %d: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// uaaClient returns the go-uaa client which is only configured when an uaa
+// client is given to the provider
+func uaaClient(s *managers.Session) (*uaaapi.API, diag.Diagnostics) {
+	if s.ClientUAAAPI == nil {
+		return nil, diag.Errorf("uaa_client_id and uaa_client_secret must be set on the provider to manage uaa resources")
+	}
+	return s.ClientUAAAPI, nil
+}
+
+// uaaRequest calls the uaa api for the requests go-uaa does not support, e.g.
+// updates which require an If-Match header
+func uaaRequest(api *uaaapi.API, method, path string, body interface{}, result interface{}, headers ...string) error {
+	data := ""
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		data = string(b)
+	}
+	headers = append(headers, "Content-Type: application/json", "Accept: application/json")
+
+	_, resBody, status, err := api.Curl(path, method, data, headers)
+	if err != nil {
+		return err
+	}
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return UAAStatusError{
+			Method:     method,
+			Path:       path,
+			StatusCode: status,
+			Body:       strings.TrimSpace(resBody),
+		}
+	}
+	if result != nil && resBody != "" {
+		return json.Unmarshal([]byte(resBody), result)
+	}
+	return nil
+}
+
+// uaaFilterValue escapes a value used in a scim filter
+func uaaFilterValue(v string) string {
+	return strings.ReplaceAll(v, `"`, `\"`)
+}
@@ -1,7 +1,9 @@
 package cloudfoundry
 
 import (
+	"bytes"
 	"fmt"
+	"net"
 	"strings"
 )
 
@@ -43,3 +45,45 @@ func validateEnvMapKeysPattern(v interface{}, k string) (warnings []string, erro
 
 	return warnings, errors
 }
+
+func validatePortRange(v interface{}, k string) (warnings []string, errors []error) {
+	start, end, err := portRangeParse(v.(string))
+	if err != nil {
+		return warnings, append(errors, fmt.Errorf("%q: invalid port or port range %q, expected format is 8080 or 8080-8090", k, v))
+	}
+	if start < 1 || end > 65535 || start > end {
+		errors = append(errors, fmt.Errorf("%q: invalid port range %q, ports must be between 1 and 65535 and start must not exceed end", k, v))
+	}
+	return warnings, errors
+}
+
+// ipDestinationParse checks an IP address, a CIDR or an IP range
+// (10.0.0.1-10.0.0.9) of the same family with start not exceeding end
+func ipDestinationParse(dest string) error {
+	if strings.Contains(dest, "/") {
+		_, _, err := net.ParseCIDR(dest)
+		return err
+	}
+	bounds := strings.Split(dest, "-")
+	if len(bounds) > 2 {
+		return fmt.Errorf("a range has exactly two bounds")
+	}
+	start := net.ParseIP(strings.TrimSpace(bounds[0]))
+	if start == nil {
+		return fmt.Errorf("%q is not an IP address", bounds[0])
+	}
+	if len(bounds) == 1 {
+		return nil
+	}
+	end := net.ParseIP(strings.TrimSpace(bounds[1]))
+	if end == nil {
+		return fmt.Errorf("%q is not an IP address", bounds[1])
+	}
+	if (start.To4() == nil) != (end.To4() == nil) {
+		return fmt.Errorf("range bounds must be of the same IP family")
+	}
+	if bytes.Compare(start.To16(), end.To16()) > 0 {
+		return fmt.Errorf("range start must not exceed range end")
+	}
+	return nil
+}